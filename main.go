@@ -2,19 +2,31 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/cli"
 	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
 	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
 	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
 )
 
 func main() {
+	args, err := cli.ParseGlobalFlags(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(cli.ExitUsage)
+	}
+
+	// Headless mode when a command is given
+	if len(args) > 0 {
+		os.Exit(cli.Run(args))
+	}
+
 	// Load or create config
-	var err error
 	config.Current, err = config.LoadOrCreate()
 	if err != nil {
 		fmt.Printf("❌ Failed to load config: %v\n", err)
@@ -23,14 +35,8 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Validate retoc directory
-	if _, err := os.Stat(config.Current.RetocDir); err != nil {
-		fmt.Printf("❌ Retoc directory not found: %s\n", config.Current.RetocDir)
-		fmt.Println("   Make sure retoc.exe is in the 'retoc' subfolder")
-		fmt.Println("\nPress Enter to exit...")
-		bufio.NewReader(os.Stdin).ReadString('\n')
-		os.Exit(1)
-	}
+	// Check retoc before anything tries to run it
+	diagnosis := retoc.Diagnose(context.Background())
 
 	// Create main menu with available tools
 	tools := []ui.Tool{
@@ -50,6 +56,9 @@ func main() {
 	// Launch main menu
 	mainMenu := ui.NewMainMenuModel(tools)
 	currentModel := tea.Model(mainMenu)
+	if diagnosis.Failed() {
+		currentModel = retoc.NewDoctorModel(diagnosis)
+	}
 
 	for {
 		p := tea.NewProgram(currentModel, tea.WithAltScreen())
@@ -76,7 +85,7 @@ func main() {
 
 		case retoc.PackSetupModel:
 			// Check if setup complete
-			if config.Current.ModsDir != "" && config.Current.PakDir != "" {
+			if config.Active().ModsDir != "" && config.Active().PakDir != "" {
 				// Discover mods and transition to pack builder
 				mods, err := retoc.DiscoverMods()
 				if err == nil && len(mods) > 0 {
@@ -88,6 +97,41 @@ func main() {
 			currentModel = retoc.NewRetocMenuModel()
 			continue
 
+		case retoc.ContainerBrowserModel:
+			// Return from Container Browser to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
+		case retoc.InstalledModsModel:
+			// Return from Installed Mods to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
+		case retoc.LoadOrderModel:
+			// Return from Load Order to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
+		case retoc.BuildHistoryModel:
+			// Return from Build History to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
+		case retoc.DoctorModel:
+			// Return from Retoc Doctor to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
+		case retoc.RetocVersionsModel:
+			// Return from Retoc Versions to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
+		case retoc.ProfileSelectModel:
+			// Return from profile selection to Retoc menu
+			currentModel = retoc.NewRetocMenuModel()
+			continue
+
 		case retoc.UnpackSetupModel:
 			// Return from Unpack Setup to Retoc menu
 			currentModel = retoc.NewRetocMenuModel()
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Exit codes
+const (
+	ExitOK      = 0
+	ExitFailure = 1
+	ExitUsage   = 2
+)
+
+// Subcommand handler
+type command struct {
+	Name        string
+	Usage       string
+	Description string
+	Run         func(ctx context.Context, args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{
+			Name:        "pack",
+			Usage:       "pack (--mod NAME... | --all [--force]) [--engine VER] [--jobs N] [--json]",
+			Description: "Build mods into Zen containers and copy them to the Paks directory",
+			Run:         runPack,
+		},
+		{
+			Name:        "unpack",
+			Usage:       "unpack [--paks DIR] [--out DIR] [--filter PATH]... [--json]",
+			Description: "Extract game assets from Zen containers to Legacy format",
+			Run:         runUnpack,
+		},
+		{
+			Name:        "uninstall",
+			Usage:       "uninstall (--mod NAME... | --all) [--force] [--json]",
+			Description: "Remove deployed mods, refusing if their files were modified unless --force",
+			Run:         runUninstall,
+		},
+		{
+			Name:        "conflicts",
+			Usage:       "conflicts [--json]",
+			Description: "List assets overridden by more than one mod or installed z_ pak",
+			Run:         runConflicts,
+		},
+		{
+			Name:        "doctor",
+			Usage:       "doctor [--json]",
+			Description: "Check that retoc is installed, runs and supports the flags the toolkit uses",
+			Run:         runDoctor,
+		},
+		{
+			Name:        "retoc",
+			Usage:       "retoc list | retoc install [--name NAME] (ZIP | DIR) | retoc use (NAME | default) | retoc remove NAME",
+			Description: "Manage retoc versions installed side by side; use pins one to the active profile",
+			Run:         runRetoc,
+		},
+		{
+			Name:        "config",
+			Usage:       "config show [--json] | config set KEY VALUE",
+			Description: "Show or change saved settings",
+			Run:         runConfig,
+		},
+		{
+			Name:        "profile",
+			Usage:       "profile list | profile (use | add | remove) NAME",
+			Description: "Manage game profiles; game settings apply to the active one",
+			Run:         runProfile,
+		},
+		{
+			Name:        "help",
+			Usage:       "help",
+			Description: "Show this help",
+			Run: func(ctx context.Context, args []string) int {
+				printUsage(os.Stdout)
+				return ExitOK
+			},
+		},
+	}
+}
+
+// Apply the options accepted before any command, --config FILE, and return
+// the remaining arguments
+func ParseGlobalFlags(args []string) ([]string, error) {
+	for len(args) > 0 {
+		var path string
+		switch arg := args[0]; {
+		case arg == "--config" || arg == "-config":
+			if len(args) < 2 {
+				return nil, fmt.Errorf("%s: expected a config file", arg)
+			}
+			path, args = args[1], args[2:]
+		case strings.HasPrefix(arg, "--config="):
+			path, args = strings.TrimPrefix(arg, "--config="), args[1:]
+		default:
+			return args, nil
+		}
+
+		if err := config.SetConfigPath(path); err != nil {
+			return nil, fmt.Errorf("--config: %w", err)
+		}
+	}
+	return args, nil
+}
+
+// Run a headless command and return the process exit code
+func Run(args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stderr)
+		return ExitUsage
+	}
+
+	var err error
+	config.Current, err = config.LoadOrCreate()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		return ExitFailure
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, cmd := range commands {
+		if cmd.Name == args[0] {
+			return cmd.Run(ctx, args[1:])
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", args[0])
+	printUsage(os.Stderr)
+	return ExitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: tinkr [--config FILE] <command> [options]")
+	fmt.Fprintln(w, "       tinkr [--config FILE]   (no command starts the interactive UI)")
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "The config file is --config, then $%s, then config.json next to the\n", config.ConfigEnv)
+	fmt.Fprintln(w, "executable if one is there (portable mode), then tinkr/config.json in the")
+	fmt.Fprintln(w, "user config directory.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %s\n      %s\n", cmd.Usage, cmd.Description)
+	}
+}
+
+// Write v to stdout as indented JSON
+func printJSON(v any) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+// Parses and stores a config value
+type configSetter func(value string) (string, error)
+
+// Settable config keys, by their JSON name. Game settings apply to the active profile.
+var configKeys = map[string]configSetter{
+	"retoc_dir":           pathSetter(func() *string { return &config.Current.RetocDir }),
+	"retoc_path":          setRetocPath,
+	"pak_dir":             pathSetter(func() *string { return &config.Active().PakDir }),
+	"mods_dir":            pathSetter(func() *string { return &config.Active().ModsDir }),
+	"output_dir":          pathSetter(func() *string { return &config.Active().OutputDir }),
+	"mods":                setMods,
+	"engine_version":      setEngineVersion,
+	"max_parallel_builds": setMaxParallelBuilds,
+	"max_backups":         setMaxBackups,
+	"aes_key":             setAESKey,
+}
+
+func pathSetter(field func() *string) configSetter {
+	return func(value string) (string, error) {
+		path, err := config.NormalizePath(value)
+		if err != nil {
+			return "", err
+		}
+		*field() = path
+		return path, nil
+	}
+}
+
+func setRetocPath(value string) (string, error) {
+	if value == "" {
+		config.Current.RetocPath = ""
+		return "(search retoc_dir, then PATH)", nil
+	}
+	return pathSetter(func() *string { return &config.Current.RetocPath })(value)
+}
+
+func setEngineVersion(value string) (string, error) {
+	if err := retoc.ValidateEngineVersion(value); err != nil {
+		return "", err
+	}
+	config.Active().EngineVersion = value
+	return value, nil
+}
+
+func setMods(value string) (string, error) {
+	config.Active().Mods = config.ParseModList(value)
+	return modsLabel(config.Active().Mods), nil
+}
+
+// Mods of a profile as shown to the user
+func modsLabel(mods []string) string {
+	if len(mods) == 0 {
+		return "(all)"
+	}
+	return strings.Join(mods, ", ")
+}
+
+func setAESKey(value string) (string, error) {
+	key := ""
+	if value != "" {
+		var err error
+		if key, err = config.NormalizeAESKey(value); err != nil {
+			return "", err
+		}
+	}
+	config.Active().AESKey = key
+	return config.MaskAESKey(key), nil
+}
+
+func setMaxParallelBuilds(value string) (string, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return "", fmt.Errorf("expected a non-negative number (0 means automatic)")
+	}
+	config.Current.MaxParallelBuilds = n
+	return value, nil
+}
+
+func setMaxBackups(value string) (string, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return "", fmt.Errorf("expected a non-negative number (0 means the default of 5)")
+	}
+	config.Current.MaxBackups = n
+	return value, nil
+}
+
+func runConfig(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "config: expected 'show' or 'set'")
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "show":
+		return runConfigShow(args[1:])
+	case "set":
+		return runConfigSet(args[1:])
+	}
+
+	fmt.Fprintf(os.Stderr, "config: unknown action: %s\n", args[0])
+	return ExitUsage
+}
+
+func runConfigShow(args []string) int {
+	fs := flag.NewFlagSet("config show", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print config as JSON")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if *jsonOut {
+		// Copy profiles so keys can be masked without touching the live config
+		shown := config.Current
+		shown.Profiles = nil
+		for _, p := range config.Current.Profiles {
+			masked := *p
+			masked.AESKey = config.MaskAESKey(p.AESKey)
+			shown.Profiles = append(shown.Profiles, &masked)
+		}
+		printJSON(shown)
+		return ExitOK
+	}
+
+	profile := config.Active()
+	if path, err := config.ConfigPath(); err == nil {
+		fmt.Printf("%-20s %s\n", "config:", path)
+	}
+	fmt.Printf("%-20s %s\n", "retoc_dir:", config.Current.RetocDir)
+	fmt.Printf("%-20s %s\n", "retoc_path:", config.Current.RetocPath)
+	fmt.Printf("%-20s %d\n", "max_parallel_builds:", config.ParallelBuilds())
+	fmt.Printf("%-20s %d\n", "max_backups:", config.BackupLimit())
+	fmt.Printf("%-20s %s\n", "profile:", profile.Name)
+	fmt.Printf("%-20s %s\n", "pak_dir:", profile.PakDir)
+	fmt.Printf("%-20s %s\n", "mods_dir:", profile.ModsDir)
+	fmt.Printf("%-20s %s\n", "output_dir:", profile.OutputDir)
+	fmt.Printf("%-20s %s\n", "mods:", modsLabel(profile.Mods))
+	fmt.Printf("%-20s %s\n", "engine_version:", profile.EngineVersion)
+	fmt.Printf("%-20s %s\n", "aes_key:", config.MaskAESKey(profile.AESKey))
+	for name, version := range profile.ModVersions {
+		fmt.Printf("  %s: %s\n", name, version)
+	}
+	return ExitOK
+}
+
+func runConfigSet(args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, "config set: expected KEY VALUE")
+		return ExitUsage
+	}
+
+	set, ok := configKeys[args[0]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "config set: unknown key: %s\n", args[0])
+		return ExitUsage
+	}
+
+	value, err := set(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config set: invalid %s: %v\n", args[0], err)
+		return ExitUsage
+	}
+	if err := config.SaveConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "config set: failed to save config: %v\n", err)
+		return ExitFailure
+	}
+
+	fmt.Printf("%s = %s\n", args[0], value)
+	return ExitOK
+}
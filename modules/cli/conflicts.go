@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+func runConflicts(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("conflicts", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print the conflicts as JSON")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if config.Active().ModsDir == "" || config.Active().PakDir == "" {
+		fmt.Fprintln(os.Stderr, "conflicts: mods_dir and pak_dir must be set (tinkr config set ...)")
+		return ExitFailure
+	}
+
+	mods, err := retoc.DiscoverMods()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "conflicts: %v\n", err)
+		return ExitFailure
+	}
+
+	conflicts, err := retoc.FindConflicts(ctx, mods)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "conflicts: %v\n", err)
+		return ExitFailure
+	}
+
+	if *jsonOut {
+		if conflicts == nil {
+			conflicts = []retoc.Conflict{}
+		}
+		printJSON(conflicts)
+		return ExitOK
+	}
+
+	for _, c := range conflicts {
+		fmt.Println(c.Asset)
+		for _, source := range c.Sources {
+			name := source.Container
+			if source.Mod == "" {
+				name += " (installed)"
+			}
+			if source.Container == c.Winner {
+				fmt.Printf("  ✓ %s wins\n", name)
+			} else {
+				fmt.Printf("  ✗ %s overridden\n", name)
+			}
+		}
+	}
+	fmt.Printf("%d conflicting asset(s)\n", len(conflicts))
+	return ExitOK
+}
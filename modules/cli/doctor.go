@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+func runDoctor(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	jsonOut := fs.Bool("json", false, "print the checks as JSON")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	diagnosis := retoc.Diagnose(ctx)
+	if *jsonOut {
+		printJSON(diagnosis)
+	} else {
+		for _, check := range diagnosis.Checks {
+			mark := "✓"
+			switch check.Status {
+			case retoc.CheckWarn:
+				mark = "!"
+			case retoc.CheckFail:
+				mark = "✗"
+			}
+			fmt.Printf("%s %-16s %s\n", mark, check.Name, check.Detail)
+			if check.Fix != "" {
+				fmt.Printf("  %-16s → %s\n", "", check.Fix)
+			}
+		}
+	}
+
+	if diagnosis.Failed() {
+		if !*jsonOut {
+			fmt.Fprintln(os.Stderr, "doctor: retoc is not usable")
+		}
+		return ExitFailure
+	}
+	return ExitOK
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+// Repeatable string flag
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func runPack(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("pack", flag.ContinueOnError)
+	var modNames stringList
+	fs.Var(&modNames, "mod", "mod folder or display name to build (repeatable)")
+	all := fs.Bool("all", false, "build every mod in the mods directory, skipping unchanged ones")
+	force := fs.Bool("force", false, "with --all, rebuild mods even if unchanged")
+	engine := fs.String("engine", "", "engine version for this run, overriding config (e.g. UE5_3)")
+	jobs := fs.Int("jobs", 0, "maximum builds to run at once (default from config)")
+	jsonOut := fs.Bool("json", false, "print a JSON report instead of the build log")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if *jobs > 0 {
+		config.Current.MaxParallelBuilds = *jobs
+	}
+
+	if *engine != "" {
+		if err := retoc.ValidateEngineVersion(*engine); err != nil {
+			fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+			return ExitUsage
+		}
+	}
+
+	if *all == (len(modNames) > 0) {
+		fmt.Fprintln(os.Stderr, "pack: specify either --mod or --all")
+		return ExitUsage
+	}
+
+	if config.Active().ModsDir == "" || config.Active().PakDir == "" {
+		fmt.Fprintln(os.Stderr, "pack: mods_dir and pak_dir must be set (tinkr config set ...)")
+		return ExitFailure
+	}
+
+	mods, err := retoc.DiscoverMods()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+		return ExitFailure
+	}
+
+	if !*all {
+		mods, err = selectMods(mods, modNames)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "pack: %v\n", err)
+			return ExitUsage
+		}
+	}
+
+	if *engine != "" {
+		for i := range mods {
+			mods[i].EngineVersion = *engine
+		}
+	}
+
+	// Only --all skips unchanged mods; naming a mod always rebuilds it
+	report := retoc.BuildMods(ctx, mods, nil, *all && !*force)
+	if err := retoc.RecordBuild(report); err != nil {
+		fmt.Fprintf(os.Stderr, "pack: couldn't save build report: %v\n", err)
+	}
+
+	if *jsonOut {
+		printJSON(report)
+	} else {
+		for _, result := range report.Results {
+			fmt.Println(result.Log)
+			if result.Err != nil {
+				fmt.Printf("  ✗ %v\n\n", result.Err)
+			}
+		}
+		if report.RetocExecutable != "" {
+			fmt.Printf("retoc %s\n", strings.TrimSpace(report.RetocLabel()+" "+report.RetocExecutable))
+		}
+		fmt.Printf("%d built, %d unchanged, %d failed\n",
+			report.Count(retoc.StatusBuilt), report.Count(retoc.StatusUnchanged), report.Count(retoc.StatusFailed))
+		if report.Cancelled() {
+			fmt.Printf("Cancelled: %d stopped, %d not started\n", report.Count(retoc.StatusCancelled), report.Count(retoc.StatusPending))
+		}
+	}
+
+	if report.Count(retoc.StatusFailed) > 0 || report.Cancelled() {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// Resolve requested names against discovered mods
+func selectMods(mods []retoc.Mod, names []string) ([]retoc.Mod, error) {
+	var selected []retoc.Mod
+	for _, name := range names {
+		found := false
+		for _, mod := range mods {
+			if mod.Name == name || strings.EqualFold(mod.DisplayName, name) {
+				selected = append(selected, mod)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("mod not found: %s", name)
+		}
+	}
+	return selected, nil
+}
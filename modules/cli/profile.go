@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+func runProfile(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "profile: expected 'list', 'use', 'add' or 'remove'")
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "list":
+		active := config.Active()
+		for _, p := range config.Current.Profiles {
+			marker := " "
+			if p == active {
+				marker = "*"
+			}
+			fmt.Printf("%s %s\n", marker, p.Name)
+		}
+		return ExitOK
+
+	case "use", "add", "remove":
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "profile %s: expected NAME\n", args[0])
+			return ExitUsage
+		}
+
+		var err error
+		switch args[0] {
+		case "use":
+			err = config.UseProfile(args[1])
+		case "add":
+			_, err = config.AddProfile(args[1])
+		case "remove":
+			err = config.RemoveProfile(args[1])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "profile %s: %v\n", args[0], err)
+			return ExitFailure
+		}
+		return ExitOK
+	}
+
+	fmt.Fprintf(os.Stderr, "profile: unknown action: %s\n", args[0])
+	return ExitUsage
+}
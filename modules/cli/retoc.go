@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+func runRetoc(ctx context.Context, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "retoc: expected 'list', 'install', 'use' or 'remove'")
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "list":
+		installs, err := retoc.ListRetocInstalls()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "retoc list: %v\n", err)
+			return ExitFailure
+		}
+		pinned := config.Active().RetocVersion
+		marker := func(name string) string {
+			if name == pinned {
+				return "*"
+			}
+			return " "
+		}
+		fmt.Printf("%s default\n", marker(""))
+		for _, install := range installs {
+			fmt.Printf("%s %-16s %s\n", marker(install.Name), install.Name, install.Dir)
+		}
+		return ExitOK
+
+	case "install":
+		fs := flag.NewFlagSet("retoc install", flag.ContinueOnError)
+		name := fs.String("name", "", "name of the install; required unless retoc reports its version")
+		if err := fs.Parse(args[1:]); err != nil {
+			return ExitUsage
+		}
+		if fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "retoc install: expected a .zip file or folder")
+			return ExitUsage
+		}
+
+		source, err := config.NormalizePath(fs.Arg(0))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "retoc install: %v\n", err)
+			return ExitUsage
+		}
+		install, err := retoc.InstallRetoc(ctx, source, *name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "retoc install: %v\n", err)
+			return ExitFailure
+		}
+		fmt.Printf("Installed retoc %s to %s\n", install.Name, install.Dir)
+		return ExitOK
+
+	case "use", "remove":
+		if len(args) != 2 {
+			fmt.Fprintf(os.Stderr, "retoc %s: expected NAME\n", args[0])
+			return ExitUsage
+		}
+
+		var err error
+		switch {
+		case args[0] == "remove":
+			err = retoc.RemoveRetocInstall(args[1])
+		case args[1] == "default":
+			err = retoc.PinRetoc("")
+		default:
+			err = retoc.PinRetoc(args[1])
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "retoc %s: %v\n", args[0], err)
+			return ExitFailure
+		}
+		return ExitOK
+	}
+
+	fmt.Fprintf(os.Stderr, "retoc: unknown action: %s\n", args[0])
+	return ExitUsage
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+type uninstallReport struct {
+	Removed  int      `json:"removed"`
+	Modified []string `json:"modified,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+func runUninstall(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+	var names stringList
+	fs.Var(&names, "mod", "deployed container or source mod folder to remove (repeatable)")
+	all := fs.Bool("all", false, "remove everything the toolkit deployed for the active profile")
+	force := fs.Bool("force", false, "remove files even if they changed since deployment")
+	jsonOut := fs.Bool("json", false, "print a JSON report")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if *all == (len(names) > 0) {
+		fmt.Fprintln(os.Stderr, "uninstall: specify either --mod or --all")
+		return ExitUsage
+	}
+
+	if config.Active().PakDir == "" {
+		fmt.Fprintln(os.Stderr, "uninstall: pak_dir must be set (tinkr config set ...)")
+		return ExitFailure
+	}
+
+	var report uninstallReport
+	var err error
+	if *all {
+		report.Removed, report.Modified, err = retoc.UninstallAll(*force)
+	} else {
+		var containers []string
+		if containers, err = resolveInstalled(names); err != nil {
+			fmt.Fprintf(os.Stderr, "uninstall: %v\n", err)
+			return ExitUsage
+		}
+		report.Removed, report.Modified, err = retoc.Uninstall(containers, *force)
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	if *jsonOut {
+		printJSON(report)
+	} else {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "uninstall: %v\n", err)
+			for _, name := range report.Modified {
+				fmt.Fprintf(os.Stderr, "  modified: %s\n", name)
+			}
+			if len(report.Modified) > 0 && !*force {
+				fmt.Fprintln(os.Stderr, "Nothing was removed; use --force to remove them anyway")
+			}
+		}
+		fmt.Printf("Removed %d file(s)\n", report.Removed)
+	}
+
+	if err != nil {
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// Resolve names against installed containers and the mods they came from
+func resolveInstalled(names []string) ([]string, error) {
+	installed, err := retoc.ListInstalledMods()
+	if err != nil {
+		return nil, err
+	}
+
+	var containers []string
+	for _, name := range names {
+		found := false
+		for _, mod := range installed {
+			if strings.EqualFold(mod.Name, name) || strings.EqualFold(mod.Source, name) {
+				containers = append(containers, mod.Name)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("no installed mod matches %s", name)
+		}
+	}
+	return containers, nil
+}
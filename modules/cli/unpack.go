@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/retoc"
+)
+
+type unpackReport struct {
+	PaksDir   string   `json:"paks_dir"`
+	OutputDir string   `json:"output_dir"`
+	Filters   []string `json:"filters,omitempty"`
+	Extracted int      `json:"extracted"`
+	Failed    int      `json:"failed"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func runUnpack(ctx context.Context, args []string) int {
+	fs := flag.NewFlagSet("unpack", flag.ContinueOnError)
+	paksDir := fs.String("paks", config.Active().PakDir, "game Paks directory")
+	outputDir := fs.String("out", config.Active().OutputDir, "output directory for legacy assets")
+	var filters stringList
+	fs.Var(&filters, "filter", "only extract assets whose path contains this (repeatable)")
+	jsonOut := fs.Bool("json", false, "print a JSON report instead of retoc output")
+	if err := fs.Parse(args); err != nil {
+		return ExitUsage
+	}
+
+	if *paksDir == "" || *outputDir == "" {
+		fmt.Fprintln(os.Stderr, "unpack: --paks and --out are required when not set in config")
+		return ExitUsage
+	}
+
+	paks, err := config.NormalizePath(*paksDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unpack: invalid paks directory: %v\n", err)
+		return ExitUsage
+	}
+	if _, err := os.Stat(paks); err != nil {
+		fmt.Fprintf(os.Stderr, "unpack: directory not found: %s\n", paks)
+		return ExitFailure
+	}
+
+	out, err := config.NormalizePath(*outputDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unpack: invalid output directory: %v\n", err)
+		return ExitUsage
+	}
+	if err := os.MkdirAll(out, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "unpack: couldn't create directory: %v\n", err)
+		return ExitFailure
+	}
+
+	var lines chan string
+	done := make(chan struct{})
+	if *jsonOut {
+		close(done)
+	} else {
+		lines = make(chan string, 64)
+		go func() {
+			defer close(done)
+			for line := range lines {
+				fmt.Println(line)
+			}
+		}()
+	}
+
+	result, err := retoc.UnpackGame(ctx, lines, paks, out, filters)
+	if lines != nil {
+		close(lines)
+	}
+	<-done
+
+	report := unpackReport{
+		PaksDir:   result.PaksDir,
+		OutputDir: result.OutputDir,
+		Filters:   result.Filters,
+		Extracted: result.Extracted,
+		Failed:    result.Failed,
+	}
+	if err != nil {
+		report.Error = err.Error()
+	}
+
+	if *jsonOut {
+		printJSON(report)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "unpack: %v\n", err)
+	} else {
+		fmt.Printf("Extracted %d asset(s) (%d failed) to %s\n", report.Extracted, report.Failed, report.OutputDir)
+	}
+
+	if err != nil {
+		return ExitFailure
+	}
+	return ExitOK
+}
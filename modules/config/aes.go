@@ -0,0 +1,42 @@
+package config
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Check that key is a 32-byte AES key in hex (optionally 0x-prefixed) or base64.
+// Returns the key normalized to 0x-prefixed upper-case hex.
+func NormalizeAESKey(key string) (string, error) {
+	key = strings.TrimSpace(key)
+
+	hexKey := strings.TrimPrefix(strings.TrimPrefix(key, "0x"), "0X")
+	if raw, err := hex.DecodeString(hexKey); err == nil {
+		if len(raw) != 32 {
+			return "", fmt.Errorf("AES key must be 32 bytes, got %d", len(raw))
+		}
+		return "0x" + strings.ToUpper(hexKey), nil
+	}
+
+	if raw, err := base64.StdEncoding.DecodeString(key); err == nil {
+		if len(raw) != 32 {
+			return "", fmt.Errorf("AES key must be 32 bytes, got %d", len(raw))
+		}
+		return "0x" + strings.ToUpper(hex.EncodeToString(raw)), nil
+	}
+
+	return "", fmt.Errorf("AES key must be 64 hex characters or base64")
+}
+
+// Hide all but the last four characters of a key for display
+func MaskAESKey(key string) string {
+	if key == "" {
+		return ""
+	}
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
@@ -3,19 +3,44 @@ package config
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Application configuration paths
+// Engine version used when none is configured
+const DefaultEngineVersion = "UE5_4"
+
+// Application configuration
 type Config struct {
-	RetocDir  string `json:"retoc_dir"`
-	PakDir    string `json:"pak_dir,omitempty"`
-	ModsDir   string `json:"mods_dir,omitempty"`
-	OutputDir string `json:"output_dir,omitempty"`
+	SchemaVersion     int        `json:"schema_version"`
+	RetocDir          string     `json:"retoc_dir"`
+	RetocPath         string     `json:"retoc_path,omitempty"` // Explicit retoc binary, overriding RetocDir and PATH
+	MaxParallelBuilds int        `json:"max_parallel_builds,omitempty"`
+	MaxBackups        int        `json:"max_backups,omitempty"`
+	LastProfile       string     `json:"last_profile,omitempty"`
+	Profiles          []*Profile `json:"profiles,omitempty"`
+}
+
+// Number of retoc builds allowed to run at once.
+// Defaults to half the CPUs since retoc itself is multi-threaded.
+func ParallelBuilds() int {
+	if Current.MaxParallelBuilds > 0 {
+		return Current.MaxParallelBuilds
+	}
+	return max(1, runtime.NumCPU()/2)
+}
+
+// Number of previous builds kept per mod for rollback
+func BackupLimit() int {
+	if Current.MaxBackups > 0 {
+		return Current.MaxBackups
+	}
+	return 5
 }
 
 // Global Config
@@ -42,17 +67,64 @@ func LoadOrCreate() (Config, error) {
 		return Config{}, err
 	}
 
-	configPath := filepath.Join(exeDir, "config.json")
+	configPath, err := ConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+
+	migrated, err := migrateFromExecutableDir()
+	if err != nil {
+		return Config{}, err
+	}
+	if migrated {
+		fmt.Fprintln(os.Stderr, infoStyle.Render("Settings copied from "+exeDir+" to "+filepath.Dir(configPath)))
+	}
 
 	// Load existing config
 	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return Config{}, err
+	}
 	if err == nil {
-		var cfg Config
-		if err := json.Unmarshal(data, &cfg); err == nil {
-			if cfg.RetocDir != "" {
-				Current = cfg
-				return cfg, nil
+		cfg, version, repairs, err := parseConfig(configPath, data)
+		switch {
+		case err == nil:
+			changed := len(repairs) > 0
+			for _, repair := range repairs {
+				fmt.Fprintln(os.Stderr, infoStyle.Render(configPath+": "+repair))
+			}
+			if version < SchemaVersion {
+				// Keep the file as it was in case the upgrade has to be undone
+				if _, err := backupConfigFile(configPath, fmt.Sprintf("schema%d", version)); err != nil {
+					return cfg, err
+				}
+				changed = true
+			}
+			if cfg.RetocDir == "" {
+				cfg.RetocDir = filepath.Join(exeDir, "retoc")
+				changed = true
+			}
+
+			Current = cfg
+			Active()
+			if changed {
+				if err := saveConfig(); err != nil {
+					return cfg, err
+				}
+			}
+			return Current, nil
+
+		case errors.Is(err, errUnreadable):
+			// Nothing can be salvaged, but the file is kept for the user to recover by hand
+			backup, backupErr := backupConfigFile(configPath, "unreadable")
+			if backupErr != nil {
+				return Config{}, fmt.Errorf("%v, and it couldn't be backed up: %w", err, backupErr)
 			}
+			fmt.Fprintln(os.Stderr, infoStyle.Render(err.Error()))
+			fmt.Fprintln(os.Stderr, infoStyle.Render("It was saved as "+backup+" and a new config was created."))
+
+		default:
+			return Config{}, err
 		}
 	}
 
@@ -68,6 +140,9 @@ func LoadOrCreate() (Config, error) {
 		return cfg, err
 	}
 
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return cfg, err
+	}
 	if err := os.WriteFile(configPath, jsonData, 0644); err != nil {
 		return cfg, err
 	}
@@ -80,7 +155,10 @@ func runSetup(exeDir string) (Config, error) {
 	retocDir := filepath.Join(exeDir, "retoc")
 
 	cfg := Config{
-		RetocDir: retocDir,
+		SchemaVersion: SchemaVersion,
+		RetocDir:      retocDir,
+		LastProfile:   DefaultProfileName,
+		Profiles:      []*Profile{{Name: DefaultProfileName}},
 	}
 
 	return cfg, nil
@@ -112,7 +190,7 @@ func PromptForModsDir() (string, error) {
 	}
 
 	// Save to config
-	Current.ModsDir = modsDir
+	Active().ModsDir = modsDir
 	if err := saveConfig(); err != nil {
 		return "", err
 	}
@@ -142,7 +220,7 @@ func PromptForPakDir() (string, error) {
 	}
 
 	// Save to config
-	Current.PakDir = pakDir
+	Active().PakDir = pakDir
 	if err := saveConfig(); err != nil {
 		return "", err
 	}
@@ -174,14 +252,7 @@ func PromptForOutputDir() (string, error) {
 		return "", fmt.Errorf("directory is invalid: %w", err)
 	}
 
-	// Create directory if it doesn't exist
-	if err := os.MkdirAll(outputDir, 0755); err != nil {
-		return "", fmt.Errorf("couldn't create directory: %w", err)
-	}
-
-	// Save to config
-	Current.OutputDir = outputDir
-	if err := saveConfig(); err != nil {
+	if err := SetOutputDir(outputDir); err != nil {
 		return "", err
 	}
 
@@ -192,14 +263,26 @@ func PromptForOutputDir() (string, error) {
 	return outputDir, nil
 }
 
+// Create the output directory if needed and save it to config
+func SetOutputDir(outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("couldn't create directory: %w", err)
+	}
+
+	Active().OutputDir = outputDir
+	return saveConfig()
+}
+
 // Save the current config to disk
 func saveConfig() error {
-	exeDir, err := GetExecutableDir()
+	configPath, err := ConfigPath()
 	if err != nil {
 		return err
 	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return err
+	}
 
-	configPath := filepath.Join(exeDir, "config.json")
 	jsonData, err := json.MarshalIndent(Current, "", "  ")
 	if err != nil {
 		return err
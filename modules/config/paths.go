@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
 )
 
 // Normalize user-provided paths
@@ -41,3 +43,143 @@ func GetExecutableDir() (string, error) {
 	}
 	return filepath.Dir(exe), nil
 }
+
+// Environment variable naming the config file to use
+const ConfigEnv = "TINKR_CONFIG"
+
+// User data kept next to config.json, moved along with it on migration
+var dataFiles = []string{"build_cache.json", "last_build.json", "history", "deployments", "backups"}
+
+// Config file chosen with --config, or resolved on first use
+var configPath string
+
+// Whether configPath is the default in the user config directory, the only
+// location old configs beside the executable are migrated to
+var defaultLocation bool
+
+// Use the config file at path instead of the default location. A path not
+// ending in .json is a directory to keep config.json in; an empty path
+// restores the default.
+func SetConfigPath(path string) error {
+	defaultLocation = false
+	if path == "" {
+		configPath = ""
+		return nil
+	}
+
+	path, err := NormalizePath(path)
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(path); (err == nil && info.IsDir()) || !strings.EqualFold(filepath.Ext(path), ".json") {
+		path = filepath.Join(path, "config.json")
+	}
+	configPath = path
+	return nil
+}
+
+// Returns the path of config.json: the --config flag, then $TINKR_CONFIG,
+// then next to the executable if a config already sits there (portable
+// mode), otherwise tinkr/config.json in the user config directory
+func ConfigPath() (string, error) {
+	if configPath != "" {
+		return configPath, nil
+	}
+	if env := os.Getenv(ConfigEnv); env != "" {
+		if err := SetConfigPath(env); err != nil {
+			return "", fmt.Errorf("%s: %w", ConfigEnv, err)
+		}
+		return configPath, nil
+	}
+
+	exeDir, err := GetExecutableDir()
+	if err != nil {
+		return "", err
+	}
+	if isPortable(exeDir) {
+		configPath = filepath.Join(exeDir, "config.json")
+		return configPath, nil
+	}
+
+	userDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	configPath = filepath.Join(userDir, "tinkr", "config.json")
+	defaultLocation = true
+	return configPath, nil
+}
+
+// Returns the directory holding config.json and other user data
+func DataDir() (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Dir(path), nil
+}
+
+// A config beside the executable keeps everything there, unless the folder
+// is read-only, in which case it gets migrated to the user config directory
+func isPortable(exeDir string) bool {
+	if _, err := os.Stat(filepath.Join(exeDir, "config.json")); err != nil {
+		return false
+	}
+
+	probe, err := os.CreateTemp(exeDir, ".tinkr-write-*")
+	if err != nil {
+		return false
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return true
+}
+
+// Copy the config and user data from beside the executable into the default
+// config location, if they're not there already. The originals are left alone
+// since the executable's folder may be read-only. Returns whether anything was
+// copied.
+func migrateFromExecutableDir() (bool, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return false, err
+	}
+	if !defaultLocation {
+		return false, nil
+	}
+	exeDir, err := GetExecutableDir()
+	if err != nil {
+		return false, err
+	}
+
+	oldPath := filepath.Join(exeDir, "config.json")
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	}
+	if _, err := os.Stat(oldPath); err != nil {
+		return false, nil
+	}
+
+	dataDir := filepath.Dir(path)
+	for _, name := range dataFiles {
+		src := filepath.Join(exeDir, name)
+		info, err := os.Stat(src)
+		if err != nil {
+			continue
+		}
+		if info.IsDir() {
+			err = utils.CopyDir(src, filepath.Join(dataDir, name))
+		} else {
+			err = utils.CopyFile(src, filepath.Join(dataDir, name))
+		}
+		if err != nil {
+			return false, fmt.Errorf("migrate %s: %w", name, err)
+		}
+	}
+
+	// Copied last, so an interrupted migration is retried on the next start
+	if err := utils.CopyFile(oldPath, path); err != nil {
+		return false, fmt.Errorf("migrate config.json: %w", err)
+	}
+	return true, nil
+}
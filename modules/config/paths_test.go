@@ -0,0 +1,38 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExplicitConfigIsNotMigrated(t *testing.T) {
+	exeDir, err := GetExecutableDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldPath := filepath.Join(exeDir, "config.json")
+	if err := os.WriteFile(oldPath, []byte(`{"retoc_dir": "/tools/retoc"}`), 0644); err != nil {
+		t.Skip("executable's folder isn't writable:", err)
+	}
+	t.Cleanup(func() { os.Remove(oldPath) })
+
+	for _, env := range []bool{false, true} {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if env {
+			t.Setenv(ConfigEnv, path)
+			SetConfigPath("")
+		} else {
+			SetConfigPath(path)
+		}
+
+		migrated, err := migrateFromExecutableDir()
+		if err != nil || migrated {
+			t.Errorf("env %v: migrated = %v, %v; want false", env, migrated, err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("env %v: %s was created", env, path)
+		}
+	}
+	SetConfigPath("")
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Name of the profile created for fresh and migrated configs
+const DefaultProfileName = "default"
+
+// Per-game settings
+type Profile struct {
+	Name          string            `json:"name"`
+	PakDir        string            `json:"pak_dir,omitempty"`
+	ModsDir       string            `json:"mods_dir,omitempty"`
+	OutputDir     string            `json:"output_dir,omitempty"`
+	EngineVersion string            `json:"engine_version,omitempty"`
+	AESKey        string            `json:"aes_key,omitempty"`
+	ModVersions   map[string]string `json:"mod_versions,omitempty"`
+	Mods          []string          `json:"mods,omitempty"`          // Mod folders to include; empty means all of ModsDir
+	LoadOrder     []string          `json:"load_order,omitempty"`    // Mod folders from lowest to highest priority
+	RetocVersion  string            `json:"retoc_version,omitempty"` // Managed retoc install to use; empty means the default retoc
+}
+
+// Returns the active game profile, creating a default one if needed
+func Active() *Profile {
+	if p := Current.FindProfile(Current.LastProfile); p != nil {
+		return p
+	}
+
+	if len(Current.Profiles) == 0 {
+		Current.Profiles = append(Current.Profiles, &Profile{Name: DefaultProfileName})
+	}
+
+	Current.LastProfile = Current.Profiles[0].Name
+	return Current.Profiles[0]
+}
+
+// Split a comma-separated list of mod folder names for Profile.Mods. An empty
+// list means every mod in ModsDir.
+func ParseModList(value string) []string {
+	var mods []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			mods = append(mods, name)
+		}
+	}
+	return mods
+}
+
+// Look up a profile by name (case-insensitive)
+func (c *Config) FindProfile(name string) *Profile {
+	for _, p := range c.Profiles {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+	return nil
+}
+
+// Create a new empty profile and save it
+func AddProfile(name string) (*Profile, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("profile name is empty")
+	}
+	if Current.FindProfile(name) != nil {
+		return nil, fmt.Errorf("profile already exists: %s", name)
+	}
+
+	p := &Profile{Name: name}
+	Current.Profiles = append(Current.Profiles, p)
+	return p, saveConfig()
+}
+
+// Make a profile active and remember it as last used
+func UseProfile(name string) error {
+	p := Current.FindProfile(name)
+	if p == nil {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+
+	Current.LastProfile = p.Name
+	return saveConfig()
+}
+
+// Delete a profile; the active profile can't be removed
+func RemoveProfile(name string) error {
+	p := Current.FindProfile(name)
+	if p == nil {
+		return fmt.Errorf("profile not found: %s", name)
+	}
+	if p == Active() {
+		return errors.New("can't remove the active profile")
+	}
+
+	for i, existing := range Current.Profiles {
+		if existing == p {
+			Current.Profiles = append(Current.Profiles[:i], Current.Profiles[i+1:]...)
+			break
+		}
+	}
+	return saveConfig()
+}
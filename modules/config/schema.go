@@ -0,0 +1,233 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
+)
+
+// Layout version of config.json written by this build
+const SchemaVersion = 1
+
+// Upgrades a raw config from the schema version it's indexed by to the next.
+// Append a step here whenever the layout changes and bump SchemaVersion.
+var migrations = []func(raw map[string]json.RawMessage) error{
+	migrateLegacyProfile, // 0 → 1
+}
+
+// Game settings single-profile configs kept at the top level
+var legacyProfileKeys = []string{"pak_dir", "mods_dir", "output_dir", "engine_version", "aes_key", "mod_versions", "mods"}
+
+// Matches engine versions such as UE5_4
+var engineVersionPattern = regexp.MustCompile(`^UE\d+_\d+$`)
+
+// Problem with one config setting
+type FieldError struct {
+	Field   string
+	Problem string
+}
+
+func (e FieldError) Error() string {
+	return e.Field + ": " + e.Problem
+}
+
+// Settings in a config file that can't be used as they are
+type ValidationError struct {
+	Path   string
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%s has %d invalid setting(s):", e.Path, len(e.Fields))
+	for _, f := range e.Fields {
+		fmt.Fprintf(&s, "\n  %s", f)
+	}
+	s.WriteString("\nFix them in a text editor, then start the toolkit again")
+	return s.String()
+}
+
+// Config file that isn't JSON at all
+var errUnreadable = errors.New("config file is not valid JSON")
+
+// Decode a config file, upgrading it from older schema versions and checking
+// every setting. Also returns the schema version the file was written with,
+// and a note for each harmless problem that was fixed, which means the file
+// should be saved again.
+func parseConfig(path string, data []byte) (Config, int, []string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return Config{}, 0, nil, fmt.Errorf("%w: %v", errUnreadable, err)
+	}
+	invalid := func(field, problem string) error {
+		return &ValidationError{Path: path, Fields: []FieldError{{field, problem}}}
+	}
+
+	version := 0
+	if v, ok := raw["schema_version"]; ok {
+		if err := json.Unmarshal(v, &version); err != nil || version < 0 {
+			return Config{}, 0, nil, invalid("schema_version", "must be a whole number")
+		}
+	}
+	if version > SchemaVersion {
+		return Config{}, 0, nil, fmt.Errorf("%s was written by a newer version of the toolkit (schema %d, this build supports %d); update the toolkit", path, version, SchemaVersion)
+	}
+
+	for v := version; v < SchemaVersion; v++ {
+		if err := migrations[v](raw); err != nil {
+			return Config{}, 0, nil, fmt.Errorf("migrate config from schema %d: %w", v, err)
+		}
+	}
+	raw["schema_version"] = json.RawMessage(fmt.Sprint(SchemaVersion))
+
+	upgraded, err := json.Marshal(raw)
+	if err != nil {
+		return Config{}, 0, nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(upgraded))
+	dec.DisallowUnknownFields()
+
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &typeErr):
+			return Config{}, 0, nil, invalid(typeErr.Field, fmt.Sprintf("expected %s, got %s", typeErr.Type, typeErr.Value))
+		case strings.HasPrefix(err.Error(), "json: unknown field "):
+			return Config{}, 0, nil, invalid(strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`), "unknown setting")
+		}
+		return Config{}, 0, nil, invalid("(file)", err.Error())
+	}
+
+	if problems := cfg.Validate(); len(problems) > 0 {
+		return Config{}, 0, nil, &ValidationError{Path: path, Fields: problems}
+	}
+
+	// A renamed or deleted profile only costs the selection, so that's not
+	// worth refusing to start over
+	var repairs []string
+	if cfg.LastProfile != "" && cfg.FindProfile(cfg.LastProfile) == nil {
+		stale := cfg.LastProfile
+		cfg.LastProfile = ""
+		if len(cfg.Profiles) > 0 {
+			cfg.LastProfile = cfg.Profiles[0].Name
+		}
+		repairs = append(repairs, fmt.Sprintf("last_profile named %q, which doesn't exist; switched to %q", stale, cfg.LastProfile))
+	}
+	return cfg, version, repairs, nil
+}
+
+// Check every setting, returning one error per bad field
+func (c *Config) Validate() []FieldError {
+	var problems []FieldError
+	add := func(field, format string, args ...any) {
+		problems = append(problems, FieldError{field, fmt.Sprintf(format, args...)})
+	}
+	absolute := func(field, path string) {
+		if path != "" && !filepath.IsAbs(path) {
+			add(field, "must be an absolute path, got %q", path)
+		}
+	}
+
+	absolute("retoc_dir", c.RetocDir)
+	absolute("retoc_path", c.RetocPath)
+	if c.MaxParallelBuilds < 0 {
+		add("max_parallel_builds", "must not be negative (0 means automatic)")
+	}
+	if c.MaxBackups < 0 {
+		add("max_backups", "must not be negative (0 means the default of 5)")
+	}
+
+	seen := make(map[string]bool)
+	for i, p := range c.Profiles {
+		field := func(name string) string { return fmt.Sprintf("profiles[%d].%s", i, name) }
+		if p == nil {
+			add(fmt.Sprintf("profiles[%d]", i), "must be an object")
+			continue
+		}
+
+		name := strings.TrimSpace(p.Name)
+		switch {
+		case name == "":
+			add(field("name"), "is required")
+		case seen[strings.ToLower(name)]:
+			add(field("name"), "%q is used by another profile", p.Name)
+		}
+		seen[strings.ToLower(name)] = true
+
+		absolute(field("pak_dir"), p.PakDir)
+		absolute(field("mods_dir"), p.ModsDir)
+		absolute(field("output_dir"), p.OutputDir)
+		if p.EngineVersion != "" && !engineVersionPattern.MatchString(p.EngineVersion) {
+			add(field("engine_version"), "%q is not an engine version like UE5_4", p.EngineVersion)
+		}
+		for mod, version := range p.ModVersions {
+			if !engineVersionPattern.MatchString(version) {
+				add(field("mod_versions."+mod), "%q is not an engine version like UE5_4", version)
+			}
+		}
+		if p.AESKey != "" {
+			if _, err := NormalizeAESKey(p.AESKey); err != nil {
+				add(field("aes_key"), "%v", err)
+			}
+		}
+		if p.RetocVersion != "" && (strings.HasPrefix(p.RetocVersion, ".") || strings.ContainsAny(p.RetocVersion, `/\:`)) {
+			add(field("retoc_version"), "%q is not a retoc version name", p.RetocVersion)
+		}
+		for j, mod := range p.LoadOrder {
+			if strings.TrimSpace(mod) == "" {
+				add(field(fmt.Sprintf("load_order[%d]", j)), "is empty")
+			}
+		}
+		for j, mod := range p.Mods {
+			if strings.TrimSpace(mod) == "" {
+				add(field(fmt.Sprintf("mods[%d]", j)), "is empty")
+			}
+		}
+	}
+	return problems
+}
+
+// Move the game settings of a single-profile config into a "default" profile
+func migrateLegacyProfile(raw map[string]json.RawMessage) error {
+	legacy := make(map[string]json.RawMessage)
+	for _, key := range legacyProfileKeys {
+		if v, ok := raw[key]; ok {
+			legacy[key] = v
+			delete(raw, key)
+		}
+	}
+
+	var profiles []json.RawMessage
+	if v, ok := raw["profiles"]; ok && string(v) != "null" {
+		if err := json.Unmarshal(v, &profiles); err != nil {
+			return fmt.Errorf("profiles: %w", err)
+		}
+	}
+	// Configs that already had profiles ignored any leftover top-level settings
+	if len(profiles) > 0 || len(legacy) == 0 {
+		return nil
+	}
+
+	legacy["name"] = json.RawMessage(fmt.Sprintf("%q", DefaultProfileName))
+	profile, err := json.Marshal(legacy)
+	if err != nil {
+		return err
+	}
+	raw["profiles"] = json.RawMessage("[" + string(profile) + "]")
+	raw["last_profile"] = json.RawMessage(fmt.Sprintf("%q", DefaultProfileName))
+	return nil
+}
+
+// Copy a config file aside before it is replaced, returning the copy's path
+func backupConfigFile(path, reason string) (string, error) {
+	backup := fmt.Sprintf("%s.%s-%s", path, reason, time.Now().Format("20060102-150405"))
+	return backup, utils.CopyFile(path, backup)
+}
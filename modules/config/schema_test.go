@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConfigMigratesLegacyProfile(t *testing.T) {
+	data := `{"retoc_dir": "/tools/retoc", "pak_dir": "/games/Paks", "engine_version": "UE4_27", "mod_versions": {"ModA": "UE5_1"}}`
+
+	cfg, version, _, err := parseConfig("config.json", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 0 || cfg.SchemaVersion != SchemaVersion {
+		t.Errorf("versions = %d → %d, want 0 → %d", version, cfg.SchemaVersion, SchemaVersion)
+	}
+	if len(cfg.Profiles) != 1 || cfg.LastProfile != DefaultProfileName {
+		t.Fatalf("profiles = %+v, last = %q", cfg.Profiles, cfg.LastProfile)
+	}
+	p := cfg.Profiles[0]
+	if p.Name != DefaultProfileName || p.PakDir != "/games/Paks" || p.EngineVersion != "UE4_27" || p.ModVersions["ModA"] != "UE5_1" {
+		t.Errorf("profile = %+v", p)
+	}
+}
+
+func TestParseConfigKeepsExistingProfiles(t *testing.T) {
+	data := `{"retoc_dir": "/tools/retoc", "last_profile": "G2", "profiles": [{"name": "G2", "pak_dir": "/g2/Paks"}]}`
+
+	cfg, _, _, err := parseConfig("config.json", []byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Profiles) != 1 || cfg.Profiles[0].PakDir != "/g2/Paks" {
+		t.Errorf("profiles = %+v", cfg.Profiles)
+	}
+}
+
+func TestParseConfigRepairsMissingLastProfile(t *testing.T) {
+	tests := []struct {
+		name, data, want string
+	}{
+		{"first profile", `{"schema_version": 1, "last_profile": "Gone", "profiles": [{"name": "G1"}, {"name": "G2"}]}`, "G1"},
+		{"no profiles", `{"schema_version": 1, "last_profile": "Gone"}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _, repairs, err := parseConfig("config.json", []byte(tt.data))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.LastProfile != tt.want || len(repairs) != 1 {
+				t.Errorf("last profile = %q with repairs %q, want %q and one repair", cfg.LastProfile, repairs, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		fields []string // Fields named in the error; none means it isn't a validation error
+	}{
+		{"not JSON", `{"retoc_dir": `, nil},
+		{"newer schema", `{"schema_version": 99}`, nil},
+		{"unknown setting", `{"schema_version": 1, "colour": "red"}`, []string{"colour"}},
+		{"wrong type", `{"schema_version": 1, "max_backups": "five"}`, []string{"max_backups"}},
+		{"bad values", `{"schema_version": 1, "retoc_dir": "retoc", "max_parallel_builds": -2,
+			"profiles": [{"name": "A", "engine_version": "5.4", "aes_key": "short"}, {"name": "a", "mod_versions": {"M": "latest"}}]}`,
+			[]string{"retoc_dir", "max_parallel_builds", "profiles[0].engine_version", "profiles[0].aes_key", "profiles[1].name", "profiles[1].mod_versions.M"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, _, err := parseConfig("config.json", []byte(tt.data))
+			if err == nil {
+				t.Fatal("parsed without error")
+			}
+
+			var invalid *ValidationError
+			if !errors.As(err, &invalid) {
+				if tt.fields != nil {
+					t.Fatalf("err = %v, want a validation error", err)
+				}
+				return
+			}
+			if tt.fields == nil {
+				t.Fatalf("unexpected validation error: %v", err)
+			}
+
+			var got []string
+			for _, f := range invalid.Fields {
+				got = append(got, f.Field)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.fields, " ") {
+				t.Errorf("fields = %v, want %v", got, tt.fields)
+			}
+		})
+	}
+
+	if _, _, _, err := parseConfig("config.json", []byte(`[]`)); !errors.Is(err, errUnreadable) {
+		t.Errorf("a JSON array was not treated as unreadable: %v", err)
+	}
+}
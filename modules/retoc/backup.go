@@ -0,0 +1,293 @@
+package retoc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
+)
+
+// Layout of backup directory names, before any same-millisecond suffix
+const backupStamp = "20060102-150405.000"
+
+// Deployed files of an earlier build, saved before a new build replaced them
+type Backup struct {
+	Dir     string
+	Created time.Time
+	Files   []string
+	seq     int // Tells apart backups taken in the same millisecond
+}
+
+func backupRoot(mod Mod) (string, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "backups", profileFileName(), mod.Name), nil
+}
+
+// Copy the mod's deployed files aside before a build overwrites them. The
+// returned backup is empty if nothing was deployed.
+func backupDeployed(mod Mod) (Backup, error) {
+	var current []string
+	for _, ext := range containerExts {
+		if path, ok := deployedPath(OutputName(mod) + ext); ok {
+			current = append(current, path)
+		}
+	}
+	if len(current) == 0 {
+		return Backup{}, nil
+	}
+
+	root, err := backupRoot(mod)
+	if err != nil {
+		return Backup{}, err
+	}
+	backup := Backup{Created: time.Now()}
+	if backup.Dir, err = newBackupDir(root, backup.Created); err != nil {
+		return Backup{}, err
+	}
+
+	for _, path := range current {
+		if err := utils.CopyFile(path, filepath.Join(backup.Dir, filepath.Base(path))); err != nil {
+			os.RemoveAll(backup.Dir)
+			return Backup{}, err
+		}
+		backup.Files = append(backup.Files, filepath.Base(path))
+	}
+	return backup, nil
+}
+
+// Create an empty folder for a backup taken at created. Backups taken in the
+// same millisecond get a -1, -2, ... suffix, so two never share a folder.
+func newBackupDir(root string, created time.Time) (string, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return "", err
+	}
+	name := created.Format(backupStamp)
+	for seq := 0; ; seq++ {
+		dir := filepath.Join(root, name)
+		if seq > 0 {
+			dir += fmt.Sprintf("-%d", seq)
+		}
+		if err := os.Mkdir(dir, 0755); !os.IsExist(err) {
+			return dir, err
+		}
+	}
+}
+
+// Read the time and sequence number out of a backup folder's name
+func parseBackupName(name string) (time.Time, int, bool) {
+	stamp, suffix := name, ""
+	if len(name) > len(backupStamp) {
+		stamp, suffix = name[:len(backupStamp)], name[len(backupStamp):]
+	}
+	created, err := time.ParseInLocation(backupStamp, stamp, time.Local)
+	if err != nil {
+		return time.Time{}, 0, false
+	}
+	if suffix == "" {
+		return created, 0, true
+	}
+	seq, err := strconv.Atoi(strings.TrimPrefix(suffix, "-"))
+	if err != nil || !strings.HasPrefix(suffix, "-") || seq < 1 {
+		return time.Time{}, 0, false
+	}
+	return created, seq, true
+}
+
+// Drop the mod's oldest backups past config.BackupLimit(). Only called once a
+// deploy has succeeded, so a failed one never costs an older rollback point.
+func pruneBackups(mod Mod) error {
+	backups, err := ListBackups(mod)
+	if err != nil {
+		return err
+	}
+	for _, old := range backups[min(len(backups), config.BackupLimit()):] {
+		if err := os.RemoveAll(old.Dir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rename each .partial file in Paks over its live name. If one can't be
+// renamed, the files already replaced are put back from previous, the backup
+// taken of them, and the other partials are removed. Returns whether that left
+// Paks as it was; if not, previous holds the only copy of the old files.
+func swapInPartials(partials []string, previous Backup) (bool, error) {
+	var replaced []string
+	existed := make(map[string]bool)
+	for i, partial := range partials {
+		live := strings.TrimSuffix(partial, ".partial")
+		if _, err := os.Stat(live); err == nil {
+			existed[live] = true
+		}
+
+		if err := os.Rename(partial, live); err != nil {
+			for _, rest := range partials[i:] {
+				os.Remove(rest)
+			}
+			return putBack(replaced, existed, previous), fmt.Errorf("replace %s: %w", filepath.Base(live), err)
+		}
+		replaced = append(replaced, live)
+	}
+	return true, nil
+}
+
+// Undo a partly finished swapInPartials: files that were there before get
+// their backed up copy back, new ones are removed. Reports whether all of
+// them were undone.
+func putBack(replaced []string, existed map[string]bool, previous Backup) bool {
+	undone := true
+	for _, live := range replaced {
+		if !existed[live] {
+			if err := os.Remove(live); err != nil && !os.IsNotExist(err) {
+				undone = false
+			}
+			continue
+		}
+
+		partial := live + ".partial"
+		if err := utils.CopyFile(filepath.Join(previous.Dir, filepath.Base(live)), partial); err != nil {
+			os.Remove(partial)
+			undone = false
+			continue
+		}
+		if err := os.Rename(partial, live); err != nil {
+			os.Remove(partial)
+			undone = false
+		}
+	}
+	return undone
+}
+
+// List a mod's backups, newest first
+func ListBackups(mod Mod) ([]Backup, error) {
+	root, err := backupRoot(mod)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		created, seq, ok := parseBackupName(entry.Name())
+		if !entry.IsDir() || !ok {
+			continue
+		}
+
+		backup := Backup{Dir: filepath.Join(root, entry.Name()), Created: created, seq: seq}
+		files, _ := os.ReadDir(backup.Dir)
+		for _, f := range files {
+			backup.Files = append(backup.Files, f.Name())
+		}
+		backups = append(backups, backup)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		a, b := backups[i], backups[j]
+		if !a.Created.Equal(b.Created) {
+			return a.Created.After(b.Created)
+		}
+		return a.seq > b.seq
+	})
+	return backups, nil
+}
+
+// Put the mod's most recent backup back into the Paks directory. The build it
+// replaces is backed up first, so rolling back again undoes the rollback. The
+// build cache is left alone: the rollback sticks until the mod's sources change
+// again.
+func RollbackMod(mod Mod) (Backup, error) {
+	backups, err := ListBackups(mod)
+	if err != nil {
+		return Backup{}, err
+	}
+	if len(backups) == 0 {
+		return Backup{}, errors.New("no previous build to roll back to")
+	}
+	backup := backups[0]
+
+	current, err := backupDeployed(mod)
+	if err != nil {
+		return backup, fmt.Errorf("back up current build: %w", err)
+	}
+
+	// Same two-phase copy as a deploy, so a failure leaves Paks unchanged
+	var partials, names []string
+	abort := func(err error) (Backup, error) {
+		for _, path := range partials {
+			os.Remove(path)
+		}
+		if current.Dir != "" {
+			os.RemoveAll(current.Dir)
+		}
+		return backup, err
+	}
+
+	// Backups taken under an earlier load order get the current name
+	for _, file := range backup.Files {
+		name := OutputName(mod) + filepath.Ext(file)
+		partial := filepath.Join(config.Active().PakDir, name+".partial")
+		if err := utils.CopyFile(filepath.Join(backup.Dir, file), partial); err != nil {
+			return abort(fmt.Errorf("restore %s: %w", name, err))
+		}
+		partials = append(partials, partial)
+		names = append(names, name)
+	}
+
+	if undone, err := swapInPartials(partials, current); err != nil {
+		if !undone && current.Dir != "" {
+			return backup, fmt.Errorf("%w; rollback incomplete, the replaced build is kept in %s", err, current.Dir)
+		}
+		if !undone {
+			return backup, fmt.Errorf("%w; rollback incomplete", err)
+		}
+		return abort(err)
+	}
+
+	restored := make(map[string]bool)
+	var outputs []OutputFile
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(config.Active().PakDir, name))
+		if err != nil {
+			return backup, err
+		}
+		restored[name] = true
+		outputs = append(outputs, OutputFile{Name: name, Size: info.Size()})
+	}
+
+	// Anything the newer build deployed that the older one didn't have goes,
+	// as does a disabled copy, since the restored build is enabled
+	for _, ext := range containerExts {
+		name := OutputName(mod) + ext
+		if !restored[name] {
+			os.Remove(filepath.Join(config.Active().PakDir, name))
+		}
+		os.Remove(filepath.Join(DisabledDir(), name))
+	}
+
+	if manifest, err := loadManifest(); err == nil {
+		if err := manifest.record(mod, outputs); err == nil {
+			manifest.save()
+		}
+	}
+
+	// The replaced build now stands in for the restored one
+	return backup, os.RemoveAll(backup.Dir)
+}
@@ -0,0 +1,419 @@
+package retoc
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
+)
+
+type browseStep int
+
+const (
+	browseContainers browseStep = iota
+	browseLoading
+	browseTree
+)
+
+type ContainerBrowserModel struct {
+	step       browseStep
+	containers []string
+	listCursor int
+	utoc       string
+	info       string
+	showInfo   bool
+	root       *treeNode
+	rows       []treeRow
+	cursor     int
+	marked     map[string]*treeNode
+	filter     textinput.Model
+	filtering  bool
+	height     int
+	cancel     context.CancelFunc
+	err        error
+}
+
+func NewContainerBrowserModel() ContainerBrowserModel {
+	ti := textinput.New()
+	ti.Placeholder = "Filter paths..."
+	ti.Prompt = "/ "
+	ti.Width = 60
+
+	containers, err := ListContainers()
+	if err == nil && len(containers) == 0 {
+		err = fmt.Errorf("no .utoc containers found in %s", valueOrUnset(config.Active().PakDir))
+	}
+
+	return ContainerBrowserModel{
+		step:       browseContainers,
+		containers: containers,
+		filter:     ti,
+		height:     20,
+		err:        err,
+	}
+}
+
+func (m ContainerBrowserModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ContainerBrowserModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.height = max(5, msg.Height-10)
+		return m, nil
+
+	case ContainerLoadedMsg:
+		if m.step != browseLoading || msg.Utoc != m.utoc {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.step = browseContainers
+			m.err = msg.Err
+			return m, nil
+		}
+		m.step = browseTree
+		m.info = msg.Info
+		m.root = buildTree(msg.Entries)
+		m.marked = make(map[string]*treeNode)
+		m.cursor = 0
+		m.filter.SetValue("")
+		m.refreshRows()
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			if m.cancel != nil {
+				m.cancel()
+			}
+			return m, tea.Quit
+		}
+
+		switch m.step {
+		case browseContainers:
+			return m.updateContainers(msg)
+		case browseLoading:
+			if msg.String() == "esc" {
+				m.cancel()
+				m.step = browseContainers
+			}
+			return m, nil
+		case browseTree:
+			if m.filtering {
+				return m.updateFilter(msg)
+			}
+			return m.updateTree(msg)
+		}
+	}
+
+	return m, nil
+}
+
+func (m ContainerBrowserModel) updateContainers(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m, tea.Quit
+
+	case "backspace":
+		return m, func() tea.Msg { return ui.BackMsg{} }
+
+	case "up":
+		if m.listCursor > 0 {
+			m.listCursor--
+		}
+
+	case "down":
+		if m.listCursor < len(m.containers)-1 {
+			m.listCursor++
+		}
+
+	case "enter":
+		if len(m.containers) == 0 {
+			return m, nil
+		}
+		m.step = browseLoading
+		m.err = nil
+		m.utoc = m.containers[m.listCursor]
+
+		var ctx context.Context
+		ctx, m.cancel = context.WithCancel(context.Background())
+		return m, LoadContainerAsync(ctx, m.utoc)
+	}
+	return m, nil
+}
+
+func (m ContainerBrowserModel) updateTree(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "backspace":
+		if m.filter.Value() != "" {
+			m.filter.SetValue("")
+			m.refreshRows()
+			return m, nil
+		}
+		m.step = browseContainers
+
+	case "up":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+
+	case "down":
+		if m.cursor < len(m.rows)-1 {
+			m.cursor++
+		}
+
+	case "pgup":
+		m.cursor = max(0, m.cursor-m.height)
+
+	case "pgdown":
+		m.cursor = max(0, min(len(m.rows)-1, m.cursor+m.height))
+
+	case "enter", "right":
+		if node := m.current(); node != nil && node.isDir {
+			node.expanded = !node.expanded
+			m.refreshRows()
+		}
+
+	case "left":
+		m.collapseCurrent()
+
+	case " ":
+		if node := m.current(); node != nil {
+			if _, ok := m.marked[node.path]; ok {
+				delete(m.marked, node.path)
+			} else {
+				m.marked[node.path] = node
+			}
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+		}
+
+	case "c":
+		m.marked = make(map[string]*treeNode)
+
+	case "x":
+		filters := m.extractFilters()
+		if len(filters) == 0 {
+			return m, nil
+		}
+		if m.cancel != nil {
+			m.cancel()
+		}
+		next := NewSelectiveUnpackModel(filepath.Dir(m.utoc), filters)
+		return next, next.Init()
+
+	case "i":
+		m.showInfo = !m.showInfo
+
+	case "/":
+		m.filtering = true
+		m.filter.Focus()
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+func (m ContainerBrowserModel) updateFilter(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.filtering = false
+		m.filter.Blur()
+		return m, nil
+
+	case tea.KeyEsc:
+		m.filtering = false
+		m.filter.Blur()
+		m.filter.SetValue("")
+		m.refreshRows()
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	m.refreshRows()
+	return m, cmd
+}
+
+// Collapse the directory under the cursor, or jump to its parent
+func (m *ContainerBrowserModel) collapseCurrent() {
+	node := m.current()
+	if node == nil {
+		return
+	}
+	if node.isDir && node.expanded {
+		node.expanded = false
+		m.refreshRows()
+		return
+	}
+
+	depth := m.rows[m.cursor].depth
+	for i := m.cursor - 1; i >= 0; i-- {
+		if m.rows[i].depth < depth {
+			m.cursor = i
+			return
+		}
+	}
+}
+
+// Whether a marked directory above node already covers it
+func (m ContainerBrowserModel) coveredByMark(node *treeNode) bool {
+	for path, marked := range m.marked {
+		if marked.isDir && strings.HasPrefix(node.path, path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// Turn the marks into retoc path filters. Directories become prefixes and
+// anything already inside a marked directory is dropped.
+func (m ContainerBrowserModel) extractFilters() []string {
+	var filters []string
+	for path, node := range m.marked {
+		if m.coveredByMark(node) {
+			continue
+		}
+		filter := strings.TrimPrefix(path, "/")
+		if node.isDir {
+			filter += "/"
+		}
+		filters = append(filters, filter)
+	}
+	sort.Strings(filters)
+	return filters
+}
+
+func (m *ContainerBrowserModel) current() *treeNode {
+	if m.cursor < 0 || m.cursor >= len(m.rows) {
+		return nil
+	}
+	return m.rows[m.cursor].node
+}
+
+func (m *ContainerBrowserModel) refreshRows() {
+	m.rows = visibleRows(m.root, m.filter.Value())
+	if m.cursor >= len(m.rows) {
+		m.cursor = max(0, len(m.rows)-1)
+	}
+}
+
+func (m ContainerBrowserModel) View() string {
+	switch m.step {
+	case browseLoading:
+		s := ui.TitleStyle.Render("Browse Containers") + "\n\n"
+		s += ui.BuildingStyle.Render("⚙️  Reading "+filepath.Base(m.utoc)+"...") + "\n\n"
+		s += ui.InfoStyle.Render("ESC: Cancel")
+		return s
+	case browseTree:
+		return m.treeView()
+	}
+	return m.containersView()
+}
+
+func (m ContainerBrowserModel) containersView() string {
+	s := ui.TitleStyle.Render("Browse Containers") + "\n"
+	s += ui.InfoStyle.Render("Paks: "+valueOrUnset(config.Active().PakDir)) + "\n\n"
+
+	for i, utoc := range m.containers {
+		cursor := " "
+		if m.listCursor == i {
+			cursor = ">"
+		}
+
+		line := fmt.Sprintf("%s %s", cursor, filepath.Base(utoc))
+		size := ui.InfoStyle.Render("  " + utils.FormatSize(containerSize(utoc)))
+		if m.listCursor == i {
+			s += ui.SelectedStyle.Render(line) + size + "\n"
+		} else {
+			s += ui.NormalStyle.Render(line) + size + "\n"
+		}
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render("Error: ") + m.err.Error() + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("↑/↓: Navigate • Enter: Open • Backspace: Back • ESC: Quit")
+	return s
+}
+
+func (m ContainerBrowserModel) treeView() string {
+	s := ui.TitleStyle.Render("Browse Containers - "+filepath.Base(m.utoc)) + "\n"
+	s += ui.InfoStyle.Render(fmt.Sprintf("%d file(s), %s", m.root.files, utils.FormatSize(m.root.size)))
+	if len(m.marked) > 0 {
+		s += ui.SuccessStyle.Render(fmt.Sprintf(" • %d marked", len(m.marked)))
+	}
+	s += "\n"
+
+	if m.showInfo {
+		s += "\n" + ui.InfoStyle.Render(strings.TrimSpace(m.info)) + "\n"
+	}
+
+	if m.filtering || m.filter.Value() != "" {
+		s += m.filter.View() + "\n"
+	}
+	s += "\n"
+
+	if len(m.rows) == 0 {
+		s += ui.InfoStyle.Render("No matching files.") + "\n"
+	}
+
+	// Keep the cursor inside the visible window
+	start := 0
+	if m.cursor >= m.height {
+		start = m.cursor - m.height + 1
+	}
+	end := min(len(m.rows), start+m.height)
+
+	for i := start; i < end; i++ {
+		s += m.renderRow(m.rows[i], i == m.cursor) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("↑/↓: Navigate • Enter/→: Expand • ←: Collapse • Space: Mark • X: Extract marked • C: Clear marks • /: Filter • I: Info • ESC: Back")
+	return s
+}
+
+func (m ContainerBrowserModel) renderRow(row treeRow, selected bool) string {
+	node := row.node
+	indent := strings.Repeat("  ", row.depth)
+
+	icon := "  "
+	if node.isDir {
+		icon = "▸ "
+		if node.expanded || m.filter.Value() != "" {
+			icon = "▾ "
+		}
+	}
+
+	cursor := " "
+	if selected {
+		cursor = ">"
+	}
+
+	checkbox := "[ ]"
+	if _, ok := m.marked[node.path]; ok {
+		checkbox = "[✓]"
+	} else if m.coveredByMark(node) {
+		checkbox = "[·]"
+	}
+
+	line := fmt.Sprintf("%s %s %s%s%s", cursor, checkbox, indent, icon, node.name)
+	detail := "  " + utils.FormatSize(node.size)
+	if node.isDir {
+		detail += fmt.Sprintf(" • %d file(s)", node.files)
+	}
+
+	if selected {
+		return ui.SelectedStyle.Render(line) + ui.InfoStyle.Render(detail)
+	}
+	return ui.NormalStyle.Render(line) + ui.InfoStyle.Render(detail)
+}
@@ -0,0 +1,170 @@
+package retoc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Container extensions retoc writes for a mod; .sig only for signed builds
+var containerExts = []string{".utoc", ".ucas", ".pak", ".sig"}
+
+// Build cache entry for one mod
+type cacheEntry struct {
+	Fingerprint string    `json:"fingerprint"`
+	Outputs     []string  `json:"outputs"`
+	BuiltAt     time.Time `json:"built_at"`
+}
+
+// Remembers what each mod was last built from, keyed by mod path
+type buildCache struct {
+	mu      sync.Mutex
+	Entries map[string]cacheEntry `json:"entries"`
+}
+
+func buildCachePath() (string, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "build_cache.json"), nil
+}
+
+// Load the build cache; a missing or unreadable cache is treated as empty
+func loadBuildCache() *buildCache {
+	cache := &buildCache{Entries: make(map[string]cacheEntry)}
+
+	path, err := buildCachePath()
+	if err != nil {
+		return cache
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil || cache.Entries == nil {
+		cache.Entries = make(map[string]cacheEntry)
+	}
+	return cache
+}
+
+// Save the build cache to disk
+func (c *buildCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path, err := buildCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Report whether mod was last built from the same inputs and its outputs are
+// still deployed. Disabled outputs count, so a rebuild doesn't re-enable them.
+func (c *buildCache) upToDate(mod Mod, fingerprint string) bool {
+	c.mu.Lock()
+	entry, ok := c.Entries[mod.Path]
+	c.mu.Unlock()
+
+	if !ok || entry.Fingerprint != fingerprint || len(entry.Outputs) == 0 {
+		return false
+	}
+
+	for _, name := range entry.Outputs {
+		if _, err := os.Stat(filepath.Join(config.Active().PakDir, name)); err == nil {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(DisabledDir(), name)); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Record a successful build of mod
+func (c *buildCache) record(mod Mod, fingerprint string) {
+	var outputs []string
+	for _, ext := range containerExts {
+		name := OutputName(mod) + ext
+		if _, err := os.Stat(filepath.Join(config.Active().PakDir, name)); err == nil {
+			outputs = append(outputs, name)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[mod.Path] = cacheEntry{
+		Fingerprint: fingerprint,
+		Outputs:     outputs,
+		BuiltAt:     time.Now(),
+	}
+}
+
+// Hash the path, size and mtime of every file under the mod,
+// together with the engine version and retoc binary used to build it
+func modFingerprint(mod Mod) (string, error) {
+	type fileStamp struct {
+		path  string
+		size  int64
+		mtime int64
+	}
+
+	var files []fileStamp
+	err := filepath.WalkDir(mod.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(mod.Path, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, fileStamp{filepath.ToSlash(rel), info.Size(), info.ModTime().UnixNano()})
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+
+	h := sha256.New()
+	fmt.Fprintf(h, "engine=%s\n", engineVersionFor(mod))
+	if info, err := os.Stat(retocExecutable()); err == nil {
+		fmt.Fprintf(h, "retoc=%d:%d\n", info.Size(), info.ModTime().UnixNano())
+	}
+	for _, f := range files {
+		fmt.Fprintf(h, "%s\x00%d\x00%d\n", f.path, f.size, f.mtime)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
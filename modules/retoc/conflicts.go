@@ -0,0 +1,187 @@
+package retoc
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Container that overrides an asset
+type ConflictSource struct {
+	Container string `json:"container"`
+	Mod       string `json:"mod,omitempty"` // display name, empty for installed paks
+}
+
+// Asset overridden by more than one container
+type Conflict struct {
+	Asset   string           `json:"asset"`
+	Sources []ConflictSource `json:"sources"` // in load order, winner last
+	Winner  string           `json:"winner"`
+}
+
+type ConflictsMsg struct {
+	Conflicts []Conflict
+	Err       error
+}
+
+// Find assets overridden by more than one of mods or the z_ paks installed in
+// the active profile's Paks directory
+func FindConflicts(ctx context.Context, mods []Mod) ([]Conflict, error) {
+	owners := make(map[string][]ConflictSource)
+	assets := make(map[string]string)
+
+	add := func(source ConflictSource, paths []string) {
+		seen := make(map[string]bool)
+		for _, path := range paths {
+			asset, ok := assetKey(path)
+			if !ok {
+				continue
+			}
+			key := strings.ToLower(asset)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if _, ok := assets[key]; !ok {
+				assets[key] = asset
+			}
+			owners[key] = append(owners[key], source)
+		}
+	}
+
+	ours := make(map[string]bool)
+	for _, mod := range mods {
+		paths, err := modFiles(mod)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s: %w", mod.DisplayName, err)
+		}
+		ours[strings.ToLower(OutputName(mod))] = true
+		add(ConflictSource{Container: OutputName(mod), Mod: mod.DisplayName}, paths)
+	}
+
+	installed, err := filepath.Glob(filepath.Join(config.Active().PakDir, "z_*.utoc"))
+	if err != nil {
+		return nil, err
+	}
+	for _, utoc := range installed {
+		name := strings.TrimSuffix(filepath.Base(utoc), filepath.Ext(utoc))
+		// Our own mods' previous builds would just conflict with themselves
+		if ours[strings.ToLower(name)] {
+			continue
+		}
+
+		entries, err := ListContainerFiles(ctx, utoc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s: %w", filepath.Base(utoc), err)
+		}
+		paths := make([]string, len(entries))
+		for i, entry := range entries {
+			paths[i] = entry.Path
+		}
+		add(ConflictSource{Container: name}, paths)
+	}
+
+	var conflicts []Conflict
+	for key, sources := range owners {
+		if len(sources) < 2 {
+			continue
+		}
+		sort.SliceStable(sources, func(i, j int) bool {
+			return loadsBefore(sources[i].Container, sources[j].Container)
+		})
+		conflicts = append(conflicts, Conflict{
+			Asset:   assets[key],
+			Sources: sources,
+			Winner:  sources[len(sources)-1].Container,
+		})
+	}
+
+	sort.Slice(conflicts, func(i, j int) bool {
+		return strings.ToLower(conflicts[i].Asset) < strings.ToLower(conflicts[j].Asset)
+	})
+	return conflicts, nil
+}
+
+// Check for conflicts in the background
+func FindConflictsAsync(ctx context.Context, mods []Mod) tea.Cmd {
+	return func() tea.Msg {
+		conflicts, err := FindConflicts(ctx, mods)
+		return ConflictsMsg{Conflicts: conflicts, Err: err}
+	}
+}
+
+// Files in a mod folder, relative to the mod folder
+func modFiles(mod Mod) ([]string, error) {
+	var paths []string
+	err := filepath.WalkDir(mod.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(mod.Path, path)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, rel)
+		return nil
+	})
+	return paths, err
+}
+
+// Asset path relative to the Content root, without extension, so that
+// .uasset/.uexp/.ubulk of one asset collapse together. Accepts both
+// "Project/Content/..." and "/Game/..." style paths.
+func assetKey(path string) (string, bool) {
+	parts := strings.Split(filepath.ToSlash(path), "/")
+
+	root := -1
+	for i, part := range parts[:len(parts)-1] {
+		if strings.EqualFold(part, "Content") {
+			root = i
+			break
+		}
+	}
+	if root < 0 && len(parts) > 2 && parts[0] == "" && strings.EqualFold(parts[1], "Game") {
+		root = 1
+	}
+	if root < 0 {
+		return "", false
+	}
+
+	asset := strings.Join(parts[root+1:], "/")
+	return strings.TrimSuffix(asset, filepath.Ext(asset)), true
+}
+
+// Whether container a is mounted before b, so that b wins any asset both contain
+func loadsBefore(a, b string) bool {
+	if pa, pb := mountPriority(a), mountPriority(b); pa != pb {
+		return pa < pb
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
+
+// Unreal's pak order bonus: _P patch paks get 100 per chunk version, where
+// z_Name_0003_P counts as version 4 and a bare _P as version 1
+func mountPriority(name string) int {
+	lower := strings.ToLower(name)
+	if !strings.HasSuffix(lower, "_p") {
+		return 0
+	}
+
+	version := 1
+	parts := strings.Split(strings.TrimSuffix(lower, "_p"), "_")
+	if n, err := strconv.Atoi(parts[len(parts)-1]); err == nil && n >= 1 {
+		version = n + 1
+	}
+	return 100 * version
+}
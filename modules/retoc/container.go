@@ -0,0 +1,116 @@
+package retoc
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// File in a container's directory index
+type ContainerEntry struct {
+	Path string
+	Size int64
+}
+
+// List the .utoc containers in the active profile's Paks directory
+func ListContainers() ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(config.Active().PakDir, "*.utoc"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Run retoc info on a container
+func ContainerInfo(ctx context.Context, utoc string) (string, error) {
+	stdout, stderr, err := currentRunner().Info(ctx, utoc)
+	if err != nil {
+		return "", fmt.Errorf("retoc info failed: %w: %s", err, strings.TrimSpace(stderr))
+	}
+	return stdout, nil
+}
+
+// Run retoc list on a container and parse its directory index
+func ListContainerFiles(ctx context.Context, utoc string) ([]ContainerEntry, error) {
+	stdout, stderr, err := currentRunner().List(ctx, utoc)
+	if err != nil {
+		return nil, fmt.Errorf("retoc list failed: %w: %s", err, strings.TrimSpace(stderr))
+	}
+	return parseListOutput(stdout), nil
+}
+
+// Pull file paths, and sizes where present, out of retoc list output. The
+// path starts at the first field containing a slash and runs to the end of the
+// line, so it may contain spaces; the size is the first plain number before it.
+// Lines without a path, like headers, are skipped, and a leading ../../../
+// mount point prefix is dropped.
+func parseListOutput(output string) []ContainerEntry {
+	var entries []ContainerEntry
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		fields := strings.Fields(line)
+
+		pathIndex := slices.IndexFunc(fields, func(field string) bool {
+			return strings.Contains(field, "/")
+		})
+		if pathIndex < 0 {
+			continue
+		}
+
+		// No earlier field has a slash, so this finds the path's own start
+		path := line[strings.Index(line, fields[pathIndex]):]
+		for strings.HasPrefix(path, "../") {
+			path = path[len("../"):]
+		}
+
+		entry := ContainerEntry{Path: path}
+		for _, field := range fields[:pathIndex] {
+			if size, err := strconv.ParseInt(field, 10, 64); err == nil {
+				entry.Size = size
+				break
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+type ContainerLoadedMsg struct {
+	Utoc    string
+	Info    string
+	Entries []ContainerEntry
+	Err     error
+}
+
+// Load a container's info and directory index in the background
+func LoadContainerAsync(ctx context.Context, utoc string) tea.Cmd {
+	return func() tea.Msg {
+		info, err := ContainerInfo(ctx, utoc)
+		if err != nil {
+			return ContainerLoadedMsg{Utoc: utoc, Err: err}
+		}
+		entries, err := ListContainerFiles(ctx, utoc)
+		return ContainerLoadedMsg{Utoc: utoc, Info: info, Entries: entries, Err: err}
+	}
+}
+
+// Size of a container's .utoc and .ucas on disk
+func containerSize(utoc string) int64 {
+	var size int64
+	for _, path := range []string{utoc, strings.TrimSuffix(utoc, ".utoc") + ".ucas"} {
+		if info, err := os.Stat(path); err == nil {
+			size += info.Size()
+		}
+	}
+	return size
+}
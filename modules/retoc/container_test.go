@@ -0,0 +1,52 @@
+package retoc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseListOutput(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   []ContainerEntry
+	}{
+		{
+			name: "sizes and mount point",
+			output: `ChunkId                   Type              Size  Path
+a1b2c3d4e5f6a7b8c9d0e1f2  ExportBundleData  18432 ../../../Game/Content/Weapons/Axe.uasset
+a1b2c3d4e5f6a7b8c9d0e1f3  BulkData          2048  ../../../Game/Content/Weapons/Axe.ubulk
+`,
+			want: []ContainerEntry{
+				{Path: "Game/Content/Weapons/Axe.uasset", Size: 18432},
+				{Path: "Game/Content/Weapons/Axe.ubulk", Size: 2048},
+			},
+		},
+		{
+			name:   "path with spaces",
+			output: "a1b2c3d4e5f6a7b8c9d0e1f4  ExportBundleData  512  ../../../Game/Content/My Mod/Big Axe 2.uasset\r\n",
+			want:   []ContainerEntry{{Path: "Game/Content/My Mod/Big Axe 2.uasset", Size: 512}},
+		},
+		{
+			name:   "no sizes",
+			output: "/Game/Weapons/Axe.uasset\n/Game/Weapons/Bow.uasset\n",
+			want: []ContainerEntry{
+				{Path: "/Game/Weapons/Axe.uasset"},
+				{Path: "/Game/Weapons/Bow.uasset"},
+			},
+		},
+		{
+			name:   "lines without a path",
+			output: "Container: pakchunk0-Windows.utoc\n\n000000000000000000000000  ContainerHeader  96\n",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseListOutput(tt.output); !slices.Equal(got, tt.want) {
+				t.Errorf("entries = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
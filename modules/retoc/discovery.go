@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
@@ -12,7 +13,8 @@ import (
 
 // Scans the configured mods directory and returns all valid mod folders
 func DiscoverMods() ([]Mod, error) {
-	entries, err := os.ReadDir(config.Current.ModsDir)
+	profile := config.Active()
+	entries, err := os.ReadDir(profile.ModsDir)
 	if err != nil {
 		return nil, err
 	}
@@ -21,10 +23,14 @@ func DiscoverMods() ([]Mod, error) {
 	for _, entry := range entries {
 		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
 			folderName := entry.Name()
+			if len(profile.Mods) > 0 && !slices.Contains(profile.Mods, folderName) {
+				continue
+			}
 			mods = append(mods, Mod{
-				Name:        folderName,
-				DisplayName: utils.FormatDisplayName(folderName),
-				Path:        filepath.Join(config.Current.ModsDir, folderName),
+				Name:          folderName,
+				DisplayName:   utils.FormatDisplayName(folderName),
+				Path:          filepath.Join(profile.ModsDir, folderName),
+				EngineVersion: profile.ModVersions[folderName],
 			})
 		}
 	}
@@ -0,0 +1,291 @@
+package retoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Oodle compression library retoc loads from its own folder on Windows
+const oodleLibrary = "oo2core_9_win64.dll"
+
+// How long a single retoc probe may take before it's treated as hung
+const probeTimeout = 10 * time.Second
+
+// Where the retoc binary was found
+const (
+	SourcePinned   = "pinned version"
+	SourceConfig   = "retoc_path"
+	SourceRetocDir = "retoc_dir"
+	SourceBundled  = "bundled"
+	SourcePath     = "PATH"
+)
+
+// Outcome of a health check
+type CheckStatus string
+
+const (
+	CheckOK   CheckStatus = "ok"
+	CheckWarn CheckStatus = "warn"
+	CheckFail CheckStatus = "fail"
+)
+
+// One health check and, if it didn't pass, how to fix it
+type Check struct {
+	Name   string      `json:"name"`
+	Status CheckStatus `json:"status"`
+	Detail string      `json:"detail"`
+	Fix    string      `json:"fix,omitempty"`
+}
+
+// Result of checking the retoc install
+type Diagnosis struct {
+	Executable string  `json:"executable,omitempty"`
+	Source     string  `json:"source,omitempty"`
+	Version    string  `json:"version,omitempty"`
+	Checks     []Check `json:"checks"`
+}
+
+type DiagnosisMsg struct {
+	Diagnosis Diagnosis
+}
+
+// Whether any check failed outright
+func (d Diagnosis) Failed() bool {
+	for _, check := range d.Checks {
+		if check.Status == CheckFail {
+			return true
+		}
+	}
+	return false
+}
+
+// Name of the retoc executable on this platform
+func retocBinaryName() string {
+	if runtime.GOOS == "windows" {
+		return "retoc.exe"
+	}
+	return "retoc"
+}
+
+// Find the retoc binary: the managed install the active profile is pinned
+// to, the configured retoc_path, then the configured retoc_dir, then the
+// retoc folder next to the toolkit, then PATH
+func ResolveRetoc() (path, source string, err error) {
+	if pinned := config.Active().RetocVersion; pinned != "" {
+		install, err := FindRetocInstall(pinned)
+		if err != nil {
+			return "", "", fmt.Errorf("%w (pinned by profile %s)", err, config.Active().Name)
+		}
+		return install.Executable, SourcePinned, nil
+	}
+
+	if config.Current.RetocPath != "" {
+		if _, err := os.Stat(config.Current.RetocPath); err != nil {
+			return "", "", fmt.Errorf("retoc_path not found: %s", config.Current.RetocPath)
+		}
+		return config.Current.RetocPath, SourceConfig, nil
+	}
+
+	candidates := []struct{ dir, source string }{
+		{config.Current.RetocDir, SourceRetocDir},
+	}
+	if exeDir, err := config.GetExecutableDir(); err == nil {
+		candidates = append(candidates, struct{ dir, source string }{filepath.Join(exeDir, "retoc"), SourceBundled})
+	}
+	for _, c := range candidates {
+		if c.dir == "" {
+			continue
+		}
+		path := filepath.Join(c.dir, retocBinaryName())
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, c.source, nil
+		}
+	}
+
+	if path, err := exec.LookPath("retoc"); err == nil {
+		return path, SourcePath, nil
+	}
+	return "", "", errors.New("retoc not found")
+}
+
+// Check that retoc can be found and run, supports the flags the toolkit
+// passes, and has the libraries it needs
+func Diagnose(ctx context.Context) Diagnosis {
+	var d Diagnosis
+
+	path, source, err := ResolveRetoc()
+	if err != nil {
+		d.Checks = append(d.Checks, missingRetocCheck(err))
+		return d
+	}
+	d.Executable, d.Source = path, source
+	d.Checks = append(d.Checks, Check{
+		Name:   "Binary",
+		Status: CheckOK,
+		Detail: fmt.Sprintf("%s (from %s)", path, source),
+	})
+
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0111 == 0 {
+			d.Checks = append(d.Checks, Check{
+				Name:   "Permissions",
+				Status: CheckFail,
+				Detail: "retoc is not executable",
+				Fix:    "Run: chmod +x " + path,
+			})
+			return d
+		}
+	}
+
+	if runtime.GOOS == "windows" {
+		d.Checks = append(d.Checks, oodleCheck(path))
+	}
+
+	help, err := probeRetoc(ctx, path, "--help")
+	if err != nil {
+		d.Checks = append(d.Checks, Check{
+			Name:   "Runs",
+			Status: CheckFail,
+			Detail: fmt.Sprintf("retoc --help failed: %v", err),
+			Fix:    "Reinstall retoc, or check that it was built for this platform",
+		})
+		return d
+	}
+	d.Checks = append(d.Checks, Check{Name: "Runs", Status: CheckOK, Detail: "retoc --help succeeded"})
+
+	// Released builds have no --version flag, so a missing version is only worth a mention
+	if version, err := probeRetoc(ctx, path, "--version"); err == nil && parseRetocVersion(version) != "" {
+		d.Version = parseRetocVersion(version)
+		d.Checks = append(d.Checks, Check{Name: "Version", Status: CheckOK, Detail: strings.TrimSpace(version)})
+	} else {
+		d.Checks = append(d.Checks, Check{
+			Name:   "Version",
+			Status: CheckWarn,
+			Detail: "retoc doesn't report its version, so build reports can't record it",
+			Fix:    "Install it under a name with: tinkr retoc install --name NAME (ZIP | DIR), and pin it to the profile",
+		})
+	}
+
+	d.Checks = append(d.Checks, flagChecks(ctx, path, help)...)
+	return d
+}
+
+// Diagnose in the background
+func DiagnoseAsync(ctx context.Context) tea.Cmd {
+	return func() tea.Msg {
+		return DiagnosisMsg{Diagnosis: Diagnose(ctx)}
+	}
+}
+
+func missingRetocCheck(err error) Check {
+	if pinned := config.Active().RetocVersion; pinned != "" {
+		return Check{
+			Name:   "Binary",
+			Status: CheckFail,
+			Detail: err.Error(),
+			Fix:    fmt.Sprintf("Install it with: tinkr retoc install --name %s (ZIP | DIR), or unpin with: tinkr retoc use default", pinned),
+		}
+	}
+
+	check := Check{
+		Name:   "Binary",
+		Status: CheckFail,
+		Detail: fmt.Sprintf("%v (looked in %s and on PATH)", err, config.Current.RetocDir),
+		Fix:    fmt.Sprintf("Put %s in %s, or set its location with: tinkr config set retoc_path PATH", retocBinaryName(), config.Current.RetocDir),
+	}
+
+	// The release only ships the Windows build
+	if runtime.GOOS != "windows" {
+		if _, err := os.Stat(filepath.Join(config.Current.RetocDir, "retoc.exe")); err == nil {
+			check.Detail = "only retoc.exe was found, which is the Windows build"
+			check.Fix = "Build retoc for this platform from https://github.com/trumank/retoc and put it on PATH, or set retoc_path"
+		}
+	}
+	return check
+}
+
+func oodleCheck(retoc string) Check {
+	path := filepath.Join(filepath.Dir(retoc), oodleLibrary)
+	if _, err := os.Stat(path); err != nil {
+		return Check{
+			Name:   "Oodle",
+			Status: CheckFail,
+			Detail: oodleLibrary + " is missing from " + filepath.Dir(retoc),
+			Fix:    "Copy " + oodleLibrary + " from the toolkit release, or from the game's Engine/Binaries folder, next to retoc.exe",
+		}
+	}
+	return Check{Name: "Oodle", Status: CheckOK, Detail: path}
+}
+
+// Flags the toolkit passes, keyed by the subcommand whose help lists them
+var requiredFlags = []struct {
+	subcommand, flag, usedFor string
+	status                    CheckStatus
+}{
+	{"to-zen", "--version", "packing", CheckFail},
+	{"to-legacy", "--filter", "selective extraction", CheckWarn},
+	{"", "--aes-key", "encrypted games", CheckWarn},
+}
+
+// Check the help text of each subcommand for the flags the toolkit passes,
+// starting from the top-level help already fetched
+func flagChecks(ctx context.Context, retoc, topHelp string) []Check {
+	var checks []Check
+	help := map[string]string{"": topHelp}
+	for _, required := range requiredFlags {
+		text, ok := help[required.subcommand]
+		if !ok {
+			args := []string{"--help"}
+			if required.subcommand != "" {
+				args = []string{required.subcommand, "--help"}
+			}
+			text, _ = probeRetoc(ctx, retoc, args...)
+			help[required.subcommand] = text
+		}
+
+		command := strings.TrimSpace("retoc " + required.subcommand)
+		if strings.Contains(text, required.flag) {
+			checks = append(checks, Check{
+				Name:   "Flag " + required.flag,
+				Status: CheckOK,
+				Detail: command + " supports " + required.flag,
+			})
+			continue
+		}
+		checks = append(checks, Check{
+			Name:   "Flag " + required.flag,
+			Status: required.status,
+			Detail: fmt.Sprintf("%s does not list %s, which %s needs", command, required.flag, required.usedFor),
+			Fix:    "Update retoc to a newer release",
+		})
+	}
+	return checks
+}
+
+// Run retoc with args and return its combined output
+func probeRetoc(ctx context.Context, retoc string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, retoc, args...)
+	cmd.Dir = filepath.Dir(retoc)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if text := strings.TrimSpace(string(output)); text != "" {
+			return "", fmt.Errorf("%w: %s", err, text)
+		}
+		return "", err
+	}
+	return string(output), nil
+}
@@ -0,0 +1,104 @@
+package retoc
+
+import (
+	"context"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+type DoctorModel struct {
+	diagnosis Diagnosis
+	checking  bool
+}
+
+// Show an existing diagnosis, e.g. the one run at startup
+func NewDoctorModel(diagnosis Diagnosis) DoctorModel {
+	return DoctorModel{diagnosis: diagnosis}
+}
+
+// Run the checks when the screen opens
+func NewDoctorCheckModel() DoctorModel {
+	return DoctorModel{checking: true}
+}
+
+func (m DoctorModel) Init() tea.Cmd {
+	if m.checking {
+		return DiagnoseAsync(context.Background())
+	}
+	return nil
+}
+
+func (m DoctorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case DiagnosisMsg:
+		m.diagnosis = msg.Diagnosis
+		m.checking = false
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+
+		case "backspace":
+			return m, func() tea.Msg { return ui.BackMsg{} }
+
+		case "v":
+			next := NewRetocVersionsModel()
+			return next, next.Init()
+
+		case "r":
+			if !m.checking {
+				m.checking = true
+				return m, DiagnoseAsync(context.Background())
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m DoctorModel) View() string {
+	s := ui.TitleStyle.Render("Retoc Doctor") + "\n"
+	s += ui.InfoStyle.Render("Settings: "+configLocation()) + "\n\n"
+
+	if m.checking {
+		s += ui.BuildingStyle.Render("Checking retoc...") + "\n"
+		return s
+	}
+
+	for _, check := range m.diagnosis.Checks {
+		switch check.Status {
+		case CheckOK:
+			s += ui.SuccessStyle.Render("✓ "+check.Name) + ui.InfoStyle.Render("  "+check.Detail) + "\n"
+		case CheckWarn:
+			s += ui.BuildingStyle.Render("! "+check.Name) + ui.NormalStyle.Render("  "+check.Detail) + "\n"
+		default:
+			s += ui.ErrorStyle.Render("✗ "+check.Name) + ui.NormalStyle.Render("  "+check.Detail) + "\n"
+		}
+		if check.Fix != "" {
+			s += ui.InfoStyle.Render("    → "+check.Fix) + "\n"
+		}
+	}
+
+	if m.diagnosis.Failed() {
+		s += "\n" + ui.ErrorStyle.Render("retoc is not usable; packing and unpacking will fail until this is fixed") + "\n"
+	} else {
+		s += "\n" + ui.SuccessStyle.Render(strings.Join(strings.Fields("✓ retoc "+m.diagnosis.Version+" is ready"), " ")) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("R: Check again • V: Manage versions • Backspace: Back • ESC: Continue")
+	return s
+}
+
+// Where settings such as retoc_path are saved
+func configLocation() string {
+	path, err := config.ConfigPath()
+	if err != nil {
+		return "unknown"
+	}
+	return path
+}
@@ -0,0 +1,285 @@
+package retoc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Stands in for retoc: to-zen writes a .utoc/.ucas pair holding the input
+// folder's file list, list prints a fixed index, anything else fails. Like
+// released retoc it rejects --version, unless $STUB_RETOC_VERSION is set.
+const stubRetoc = `#!/bin/sh
+if [ "$1" = "--aes-key" ]; then
+	echo "using key $2"
+	shift 2
+fi
+cmd=$1
+shift
+if [ "$1" = "--help" ]; then
+	echo "Usage: retoc $cmd --version <VERSION> --filter <FILTER> <INPUT> <OUTPUT>"
+	exit 0
+fi
+case "$cmd" in
+--version)
+	if [ -z "$STUB_RETOC_VERSION" ]; then
+		echo "error: unexpected argument '--version' found" >&2
+		echo >&2
+		echo "Usage: retoc [OPTIONS] <COMMAND>" >&2
+		exit 2
+	fi
+	echo "retoc $STUB_RETOC_VERSION"
+	;;
+--help)
+	echo "Usage: retoc [OPTIONS] <COMMAND>"
+	echo
+	echo "Options:"
+	echo "  -a, --aes-key <AES_KEY>"
+	echo "  -h, --help               Print help"
+	;;
+to-zen)
+	[ "$1" = "--version" ] || exit 2
+	version=$2
+	shift 3
+	out=${2%.utoc}
+	echo "packing $1 for $version"
+	(cd "$1" && find . -type f | sort) > "$out.utoc"
+	echo "$version" > "$out.ucas"
+	;;
+list)
+	echo "/Game/Weapons/Axe.uasset"
+	echo "/Game/Weapons/Bow.uasset"
+	;;
+*)
+	echo "unknown command $cmd" >&2
+	exit 1
+	;;
+esac
+`
+
+// Install the stub as the configured retoc executable
+func installStubRetoc(t *testing.T) {
+	t.Helper()
+
+	if err := os.MkdirAll(config.Current.RetocDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(retocExecutable(), []byte(stubRetoc), 0755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEndToEndDiscoverBuildDeploy(t *testing.T) {
+	profile := useTestProfile(t, nil)
+	installStubRetoc(t)
+	writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+	writeMod(t, profile, "z_ModB_0001_P", "Content/Weapons/Bow.uasset")
+	profile.LoadOrder = []string{"z_ModB_0001_P", "ModA"}
+
+	mods, err := DiscoverMods()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mods) != 2 {
+		t.Fatalf("discovered %d mods, want 2", len(mods))
+	}
+
+	events := make(chan BuildOutputMsg, 64)
+	report := BuildMods(context.Background(), mods, events, true)
+	close(events)
+	for _, result := range report.Results {
+		if result.Status != StatusBuilt {
+			t.Fatalf("%s: status %s: %s", result.Name, result.Status, result.Error)
+		}
+	}
+
+	var lines []string
+	for event := range events {
+		lines = append(lines, event.Line)
+	}
+	if !slices.ContainsFunc(lines, func(line string) bool { return strings.HasPrefix(line, "packing ") }) {
+		t.Errorf("no retoc output streamed: %v", lines)
+	}
+
+	want := []string{
+		"z_ModA_0002_P.ucas", "z_ModA_0002_P.utoc",
+		"z_ModB_0001_P.ucas", "z_ModB_0001_P.utoc",
+	}
+	if got := dirFiles(t, profile.PakDir); !slices.Equal(got, want) {
+		t.Fatalf("Paks = %v, want %v", got, want)
+	}
+	index, err := os.ReadFile(filepath.Join(profile.PakDir, "z_ModA_0002_P.utoc"))
+	if err != nil || !strings.Contains(string(index), "Axe.uasset") {
+		t.Errorf("z_ModA_0002_P.utoc = %q, %v", index, err)
+	}
+
+	installed, err := ListInstalledMods()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(installed) != 2 || installed[0].Source != "z_ModB_0001_P" || installed[1].Source != "ModA" {
+		t.Errorf("installed = %+v", installed)
+	}
+
+	// Nothing changed, so a second run skips both mods
+	report = BuildMods(context.Background(), mods, nil, true)
+	if n := report.Count(StatusUnchanged); n != 2 {
+		t.Errorf("%d mod(s) unchanged on rebuild, want 2", n)
+	}
+}
+
+func TestEndToEndRetocFailure(t *testing.T) {
+	profile := useTestProfile(t, nil)
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	if err := os.MkdirAll(config.Current.RetocDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(retocExecutable(), []byte("#!/bin/sh\necho boom >&2\nexit 3\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := BuildMod(context.Background(), mod, nil)
+	if result.Status != StatusFailed || result.ExitCode != 3 {
+		t.Fatalf("status = %s, exit code = %d, want failed with 3", result.Status, result.ExitCode)
+	}
+	if !strings.Contains(result.Stderr, "boom") {
+		t.Errorf("stderr = %q", result.Stderr)
+	}
+	if got := dirFiles(t, profile.PakDir); len(got) != 0 {
+		t.Errorf("Paks = %v, want empty", got)
+	}
+}
+
+func TestEndToEndAESKeyRedacted(t *testing.T) {
+	profile := useTestProfile(t, nil)
+	installStubRetoc(t)
+	profile.AESKey = "0xDEADBEEF"
+
+	entries, err := ListContainerFiles(context.Background(), filepath.Join(profile.PakDir, "pakchunk0-Windows.utoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("listed %d entries, want 2", len(entries))
+	}
+
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+	result := BuildMod(context.Background(), mod, nil)
+	if result.Status != StatusBuilt {
+		t.Fatalf("status = %s: %s", result.Status, result.Error)
+	}
+	if strings.Contains(result.Stdout, profile.AESKey) || strings.Contains(result.Log, profile.AESKey) {
+		t.Errorf("AES key leaked into build output: %q", result.Stdout)
+	}
+}
+
+func TestDoctorHealthyRetoc(t *testing.T) {
+	useTestProfile(t, nil)
+	installStubRetoc(t)
+
+	// Released retoc has no --version, which mustn't stop the toolkit
+	diagnosis := Diagnose(context.Background())
+	if diagnosis.Failed() {
+		t.Fatalf("diagnosis failed: %+v", diagnosis.Checks)
+	}
+	if diagnosis.Version != "" || diagnosis.Source != SourceRetocDir {
+		t.Errorf("version = %q, source = %q", diagnosis.Version, diagnosis.Source)
+	}
+	for _, check := range diagnosis.Checks {
+		want := CheckOK
+		if check.Name == "Version" {
+			want = CheckWarn
+		}
+		if check.Status != want {
+			t.Errorf("%s: %s, want %s: %s", check.Name, check.Status, want, check.Detail)
+		}
+	}
+
+	t.Setenv("STUB_RETOC_VERSION", "0.1.2")
+	diagnosis = Diagnose(context.Background())
+	if diagnosis.Failed() || diagnosis.Version != "0.1.2" {
+		t.Errorf("version = %q: %+v", diagnosis.Version, diagnosis.Checks)
+	}
+}
+
+func TestDoctorProblems(t *testing.T) {
+	useTestProfile(t, nil)
+	t.Setenv("PATH", t.TempDir())
+
+	if diagnosis := Diagnose(context.Background()); !diagnosis.Failed() || diagnosis.Executable != "" {
+		t.Errorf("missing retoc passed: %+v", diagnosis)
+	}
+
+	// Only the Windows build present
+	installStubRetoc(t)
+	if err := os.Rename(retocExecutable(), filepath.Join(config.Current.RetocDir, "retoc.exe")); err != nil {
+		t.Fatal(err)
+	}
+	diagnosis := Diagnose(context.Background())
+	if !diagnosis.Failed() || !strings.Contains(diagnosis.Checks[0].Detail, "Windows build") {
+		t.Errorf("Windows-only install not explained: %+v", diagnosis.Checks)
+	}
+
+	// Present but not executable
+	installStubRetoc(t)
+	if err := os.Chmod(retocExecutable(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	diagnosis = Diagnose(context.Background())
+	last := diagnosis.Checks[len(diagnosis.Checks)-1]
+	if !diagnosis.Failed() || last.Name != "Permissions" {
+		t.Errorf("non-executable retoc not reported: %+v", diagnosis.Checks)
+	}
+}
+
+func TestManagedRetocInstall(t *testing.T) {
+	profile := useTestProfile(t, nil)
+
+	source := t.TempDir()
+	if err := os.WriteFile(filepath.Join(source, "retoc"), []byte(stubRetoc), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a reported version the install needs a name
+	if _, err := InstallRetoc(context.Background(), source, ""); err == nil {
+		t.Error("installed a retoc with no version and no name")
+	}
+	install, err := InstallRetoc(context.Background(), source, "nightly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InstallRetoc(context.Background(), source, "nightly"); err == nil {
+		t.Error("installing the same name twice succeeded")
+	}
+
+	t.Setenv("STUB_RETOC_VERSION", "0.1.2")
+	if versioned, err := InstallRetoc(context.Background(), source, ""); err != nil || versioned.Name != "0.1.2" {
+		t.Errorf("installed as %q, %v; want the reported version", versioned.Name, err)
+	}
+	t.Setenv("STUB_RETOC_VERSION", "")
+
+	if err := PinRetoc("nightly"); err != nil {
+		t.Fatal(err)
+	}
+	if path, source, err := ResolveRetoc(); err != nil || source != SourcePinned || path != install.Executable {
+		t.Errorf("resolved %s from %s, %v", path, source, err)
+	}
+	if err := RemoveRetocInstall("nightly"); err == nil {
+		t.Error("removed the pinned install")
+	}
+
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+	report := BuildMods(context.Background(), []Mod{mod}, nil, false)
+	if report.RetocInstall != "nightly" || report.RetocExecutable != install.Executable || report.Count(StatusBuilt) != 1 {
+		t.Errorf("report = %+v", report)
+	}
+	if report.RetocLabel() != "nightly" {
+		t.Errorf("label = %q", report.RetocLabel())
+	}
+}
@@ -0,0 +1,157 @@
+package retoc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Runner that records its calls and writes placeholder containers instead of running retoc
+type fakeRunner struct {
+	mu    sync.Mutex
+	calls []string
+
+	zenExts []string                        // Containers ToZen writes; defaults to .utoc and .ucas
+	zenErr  error                           // Returned by ToZen without writing anything
+	list    string                          // Returned by List
+	onZen   func(ctx context.Context) error // Runs before ToZen writes, e.g. to block or cancel
+}
+
+func (f *fakeRunner) record(call string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+}
+
+func (f *fakeRunner) ToZen(ctx context.Context, input, output, version string, onLine func(string)) (string, string, error) {
+	f.record("to-zen " + filepath.Base(input) + " " + version)
+	if f.onZen != nil {
+		if err := f.onZen(ctx); err != nil {
+			return "", "", err
+		}
+	}
+	if f.zenErr != nil {
+		return "", "fake failure", f.zenErr
+	}
+
+	exts := f.zenExts
+	if exts == nil {
+		exts = []string{".utoc", ".ucas"}
+	}
+	base := strings.TrimSuffix(output, filepath.Ext(output))
+	for _, ext := range exts {
+		if err := os.WriteFile(base+ext, []byte(filepath.Base(input)+ext), 0644); err != nil {
+			return "", "", err
+		}
+	}
+	if onLine != nil {
+		onLine("Packed " + filepath.Base(output))
+	}
+	return "Packed " + filepath.Base(output) + "\n", "", nil
+}
+
+func (f *fakeRunner) ToLegacy(ctx context.Context, input, output string, filters []string, onLine func(string)) (string, string, error) {
+	f.record("to-legacy " + strings.Join(filters, ","))
+	return "", "", os.MkdirAll(output, 0755)
+}
+
+func (f *fakeRunner) List(ctx context.Context, utoc string) (string, string, error) {
+	f.record("list " + filepath.Base(utoc))
+	return f.list, "", nil
+}
+
+func (f *fakeRunner) Info(ctx context.Context, utoc string) (string, string, error) {
+	f.record("info " + filepath.Base(utoc))
+	return "Container: " + filepath.Base(utoc) + "\n", "", nil
+}
+
+func (f *fakeRunner) Manifest(ctx context.Context, utoc string) (string, string, error) {
+	f.record("manifest " + filepath.Base(utoc))
+	return "{}", "", nil
+}
+
+func (f *fakeRunner) Version(ctx context.Context) (string, error) {
+	return "0.0.0-fake", nil
+}
+
+func (f *fakeRunner) Unpack(ctx context.Context, utoc, output string, onLine func(string)) (string, string, error) {
+	f.record("unpack " + filepath.Base(utoc))
+	return "", "", os.MkdirAll(output, 0755)
+}
+
+// Point the config, its data directory and a fresh game profile at a
+// temporary directory and install runner, restoring everything when the
+// test ends. Returns the profile.
+func useTestProfile(t *testing.T, runner Runner) *config.Profile {
+	t.Helper()
+
+	root := t.TempDir()
+	profile := &config.Profile{
+		Name:          "test",
+		PakDir:        filepath.Join(root, "Game", "Paks", "~mods"),
+		ModsDir:       filepath.Join(root, "mods"),
+		EngineVersion: "UE5_4",
+	}
+	for _, dir := range []string{profile.PakDir, profile.ModsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	saved := config.Current
+	config.Current = config.Config{
+		RetocDir:          filepath.Join(root, "retoc"),
+		MaxParallelBuilds: 2,
+		LastProfile:       profile.Name,
+		Profiles:          []*config.Profile{profile},
+	}
+	if err := config.SetConfigPath(filepath.Join(root, "data", "config.json")); err != nil {
+		t.Fatal(err)
+	}
+	SetRunner(runner)
+
+	t.Cleanup(func() {
+		SetRunner(nil)
+		config.SetConfigPath("")
+		config.Current = saved
+	})
+	return profile
+}
+
+// Create a mod folder with one asset in it
+func writeMod(t *testing.T, profile *config.Profile, name, asset string) Mod {
+	t.Helper()
+
+	path := filepath.Join(profile.ModsDir, name, filepath.FromSlash(asset))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return Mod{
+		Name:        name,
+		DisplayName: name,
+		Path:        filepath.Join(profile.ModsDir, name),
+	}
+}
+
+// Names of the files in dir, sorted
+func dirFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	return names
+}
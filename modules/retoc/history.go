@@ -0,0 +1,116 @@
+package retoc
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Number of build runs kept in the history directory
+const historyLimit = 50
+
+// A saved build run
+type HistoryEntry struct {
+	Path   string
+	Report BuildReport
+}
+
+func historyDir() (string, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "history"), nil
+}
+
+// Save a build run to the history directory, dropping the oldest runs past historyLimit
+func SaveBuildHistory(report BuildReport) error {
+	dir, err := historyDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	name := report.Started.Format("20060102-150405.000") + ".json"
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0644); err != nil {
+		return err
+	}
+
+	files, err := historyFiles(dir)
+	if err != nil {
+		return err
+	}
+	for len(files) > historyLimit {
+		os.Remove(filepath.Join(dir, files[0]))
+		files = files[1:]
+	}
+	return nil
+}
+
+// Load saved build runs, newest first. Unreadable entries are skipped.
+func LoadBuildHistory() ([]HistoryEntry, error) {
+	dir, err := historyDir()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := historyFiles(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	for i := len(files) - 1; i >= 0; i-- {
+		path := filepath.Join(dir, files[i])
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var report BuildReport
+		if err := json.Unmarshal(data, &report); err != nil {
+			continue
+		}
+		entries = append(entries, HistoryEntry{Path: path, Report: report})
+	}
+	return entries, nil
+}
+
+// History file names, oldest first
+func historyFiles(dir string) ([]string, error) {
+	dirEntries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, e := range dirEntries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".json") {
+			files = append(files, e.Name())
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Save the report as the last build and add it to history
+func RecordBuild(report BuildReport) error {
+	if err := SaveBuildReport(report); err != nil {
+		return err
+	}
+	return SaveBuildHistory(report)
+}
@@ -0,0 +1,292 @@
+package retoc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+type historyLevel int
+
+const (
+	historyRuns historyLevel = iota
+	historyRun
+	historyLog
+)
+
+type BuildHistoryModel struct {
+	entries   []HistoryEntry
+	level     historyLevel
+	runCursor int
+	modCursor int
+	height    int // Runs that fit on screen
+	viewport  viewport.Model
+	err       error
+}
+
+func NewBuildHistoryModel() BuildHistoryModel {
+	entries, err := LoadBuildHistory()
+	return BuildHistoryModel{
+		entries:  entries,
+		height:   20,
+		viewport: viewport.New(100, 20),
+		err:      err,
+	}
+}
+
+func (m BuildHistoryModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m BuildHistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = max(5, msg.Height-6)
+		m.height = max(5, msg.Height-6)
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			return m, tea.Quit
+		}
+
+		switch m.level {
+		case historyRuns:
+			return m.updateRuns(msg)
+		case historyRun:
+			return m.updateRun(msg)
+		case historyLog:
+			switch msg.String() {
+			case "esc", "backspace", "q":
+				m.level = historyRun
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m BuildHistoryModel) updateRuns(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		return m, tea.Quit
+
+	case "backspace":
+		return m, func() tea.Msg { return ui.BackMsg{} }
+
+	case "up":
+		if m.runCursor > 0 {
+			m.runCursor--
+		}
+
+	case "down":
+		if m.runCursor < len(m.entries)-1 {
+			m.runCursor++
+		}
+
+	case "pgup":
+		m.runCursor = max(0, m.runCursor-m.height)
+
+	case "pgdown":
+		m.runCursor = max(0, min(len(m.entries)-1, m.runCursor+m.height))
+
+	case "enter":
+		if len(m.entries) > 0 {
+			m.level = historyRun
+			m.modCursor = 0
+
+			// Start on the first failure, since that's usually why we're here
+			for i, r := range m.entries[m.runCursor].Report.Results {
+				if r.Status == StatusFailed {
+					m.modCursor = i
+					break
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m BuildHistoryModel) updateRun(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	results := m.entries[m.runCursor].Report.Results
+
+	switch msg.String() {
+	case "esc", "backspace":
+		m.level = historyRuns
+
+	case "up":
+		if m.modCursor > 0 {
+			m.modCursor--
+		}
+
+	case "down":
+		if m.modCursor < len(results)-1 {
+			m.modCursor++
+		}
+
+	case "enter":
+		if len(results) > 0 {
+			m.level = historyLog
+			m.viewport.SetContent(fullLog(results[m.modCursor]))
+			m.viewport.GotoTop()
+		}
+	}
+	return m, nil
+}
+
+// Everything recorded about one mod's build
+func fullLog(r BuildResult) string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "Mod:      %s (%s)\n", r.DisplayName, r.Name)
+	fmt.Fprintf(&s, "Status:   %s\n", r.Status)
+	fmt.Fprintf(&s, "Engine:   %s\n", r.EngineVersion)
+	fmt.Fprintf(&s, "Started:  %s\n", r.Started.Format(time.DateTime))
+	fmt.Fprintf(&s, "Duration: %s\n", r.Duration.Round(time.Millisecond))
+	fmt.Fprintf(&s, "Exit:     %d\n", r.ExitCode)
+	if r.Error != "" {
+		fmt.Fprintf(&s, "Error:    %s\n", r.Error)
+	}
+
+	s.WriteString("\n---- Log ----\n")
+	s.WriteString(r.Log)
+	if r.Stdout != "" {
+		s.WriteString("\n---- retoc stdout ----\n")
+		s.WriteString(r.Stdout)
+	}
+	if r.Stderr != "" {
+		s.WriteString("\n---- retoc stderr ----\n")
+		s.WriteString(r.Stderr)
+	}
+	return s.String()
+}
+
+func (m BuildHistoryModel) View() string {
+	switch m.level {
+	case historyRun:
+		return m.runView()
+	case historyLog:
+		return m.logView()
+	}
+	return m.runsView()
+}
+
+func (m BuildHistoryModel) runsView() string {
+	s := ui.TitleStyle.Render("Build History") + "\n\n"
+
+	if m.err != nil {
+		s += ui.ErrorStyle.Render("Error: ") + m.err.Error() + "\n"
+	} else if len(m.entries) == 0 {
+		s += ui.InfoStyle.Render("No builds recorded yet.") + "\n"
+	}
+
+	// Keep the cursor inside the visible window
+	start := 0
+	if m.runCursor >= m.height {
+		start = m.runCursor - m.height + 1
+	}
+	end := min(len(m.entries), start+m.height)
+
+	for i := start; i < end; i++ {
+		report := m.entries[i].Report
+		cursor := " "
+		if m.runCursor == i {
+			cursor = ">"
+		}
+
+		line := fmt.Sprintf("%s %s  [%s]  %d built", cursor, report.Started.Format(time.DateTime), report.Profile, report.Count(StatusBuilt))
+		if n := report.Count(StatusUnchanged); n > 0 {
+			line += fmt.Sprintf(", %d unchanged", n)
+		}
+
+		failed := report.Count(StatusFailed)
+		if failed > 0 {
+			line += fmt.Sprintf(", %d failed", failed)
+		}
+		if report.Cancelled() {
+			line += " (cancelled)"
+		}
+
+		switch {
+		case m.runCursor == i:
+			s += ui.SelectedStyle.Render(line) + "\n"
+		case failed > 0:
+			s += ui.ErrorStyle.Render(line) + "\n"
+		default:
+			s += ui.NormalStyle.Render(line) + "\n"
+		}
+	}
+
+	if len(m.entries) > m.height {
+		s += ui.InfoStyle.Render(fmt.Sprintf("  %d-%d of %d runs", start+1, end, len(m.entries))) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("↑/↓/PgUp/PgDn: Navigate • Enter: Open run • Backspace: Back • ESC: Quit")
+	return s
+}
+
+func (m BuildHistoryModel) runView() string {
+	report := m.entries[m.runCursor].Report
+
+	s := ui.TitleStyle.Render("Build "+report.Started.Format(time.DateTime)) + "\n"
+	header := fmt.Sprintf("Profile: %s • Duration: %s", report.Profile, report.Duration.Round(time.Second))
+	if label := report.RetocLabel(); label != "" {
+		header += " • retoc " + label
+	}
+	s += ui.InfoStyle.Render(header) + "\n\n"
+
+	for i, r := range report.Results {
+		cursor := " "
+		if m.modCursor == i {
+			cursor = ">"
+		}
+
+		var mark string
+		switch r.Status {
+		case StatusBuilt:
+			mark = ui.SuccessStyle.Render("✓")
+		case StatusUnchanged:
+			mark = ui.InfoStyle.Render("=")
+		case StatusCancelled:
+			mark = ui.ErrorStyle.Render("⊘")
+		case StatusPending:
+			mark = ui.InfoStyle.Render("·")
+		default:
+			mark = ui.ErrorStyle.Render("✗")
+		}
+
+		name := r.DisplayName
+		if m.modCursor == i {
+			name = ui.SelectedStyle.Render(name)
+		} else {
+			name = ui.NormalStyle.Render(name)
+		}
+
+		s += fmt.Sprintf("%s %s %s", cursor, mark, name)
+		if r.Error != "" {
+			s += ui.InfoStyle.Render(": " + r.Error)
+		}
+		s += "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("↑/↓: Navigate • Enter: View full log • ESC: Back to runs")
+	return s
+}
+
+func (m BuildHistoryModel) logView() string {
+	r := m.entries[m.runCursor].Report.Results[m.modCursor]
+
+	s := ui.TitleStyle.Render("Build Log - "+r.DisplayName) + "\n\n"
+	s += m.viewport.View() + "\n\n"
+	s += ui.InfoStyle.Render(fmt.Sprintf("%3.f%% • ↑/↓/PgUp/PgDn: Scroll • ESC: Back", m.viewport.ScrollPercent()*100))
+	return s
+}
@@ -0,0 +1,110 @@
+package retoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Container the toolkit deployed to the Paks directory
+type InstalledMod struct {
+	Name     string   // Container name without extension
+	Source   string   // Mod folder it was built from
+	Files    []string // File names, e.g. z_MyMod_0001_P.utoc
+	Size     int64
+	Disabled bool
+}
+
+// Where disabled mods are parked. Unreal mounts every pak under Paks
+// recursively, so this sits next to the Paks directory rather than inside it.
+func DisabledDir() string {
+	return filepath.Join(filepath.Dir(config.Active().PakDir), "_disabled")
+}
+
+// List the containers in the deployment manifest that are still on disk,
+// either enabled in the Paks directory or parked in the disabled folder
+func ListInstalledMods() ([]InstalledMod, error) {
+	manifest, err := loadManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	var mods []InstalledMod
+	for name, deployment := range manifest.Deployments {
+		mod, ok := findInstalled(name, config.Active().PakDir)
+		if !ok {
+			if mod, ok = findInstalled(name, DisabledDir()); !ok {
+				continue
+			}
+			mod.Disabled = true
+		}
+		mod.Source = deployment.Mod
+		mods = append(mods, mod)
+	}
+
+	sort.Slice(mods, func(i, j int) bool {
+		return loadsBefore(mods[i].Name, mods[j].Name)
+	})
+	return mods, nil
+}
+
+func findInstalled(name, dir string) (InstalledMod, bool) {
+	mod := InstalledMod{Name: name}
+	for _, ext := range containerExts {
+		info, err := os.Stat(filepath.Join(dir, name+ext))
+		if err != nil {
+			continue
+		}
+		mod.Files = append(mod.Files, name+ext)
+		mod.Size += info.Size()
+	}
+	return mod, len(mod.Files) > 0
+}
+
+// Move a mod's files into or out of the disabled folder. If any move fails
+// the ones already made are undone.
+func SetModEnabled(mod *InstalledMod, enabled bool) error {
+	if mod.Disabled != enabled {
+		return nil
+	}
+
+	from, to := config.Active().PakDir, DisabledDir()
+	if enabled {
+		from, to = to, from
+	}
+	if err := os.MkdirAll(to, 0755); err != nil {
+		return err
+	}
+
+	var moved []string
+	for _, file := range mod.Files {
+		if err := os.Rename(filepath.Join(from, file), filepath.Join(to, file)); err != nil {
+			for _, done := range moved {
+				os.Rename(filepath.Join(to, done), filepath.Join(from, done))
+			}
+			return fmt.Errorf("move %s: %w", file, err)
+		}
+		moved = append(moved, file)
+	}
+
+	mod.Disabled = !enabled
+	return nil
+}
+
+// Enable or disable every installed mod, returning how many changed
+func SetAllModsEnabled(mods []InstalledMod, enabled bool) (int, error) {
+	changed := 0
+	for i := range mods {
+		if mods[i].Disabled != enabled {
+			continue
+		}
+		if err := SetModEnabled(&mods[i], enabled); err != nil {
+			return changed, err
+		}
+		changed++
+	}
+	return changed, nil
+}
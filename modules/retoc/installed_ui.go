@@ -0,0 +1,219 @@
+package retoc
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
+)
+
+type InstalledModsModel struct {
+	mods     []InstalledMod
+	cursor   int
+	selected map[int]bool
+	status   string
+	err      error
+	confirm  *pendingUninstall
+}
+
+// Uninstall waiting for confirmation because files were modified
+type pendingUninstall struct {
+	containers []string
+	modified   []string
+}
+
+func NewInstalledModsModel() InstalledModsModel {
+	mods, err := ListInstalledMods()
+	return InstalledModsModel{
+		mods:     mods,
+		selected: make(map[int]bool),
+		err:      err,
+	}
+}
+
+func (m InstalledModsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m InstalledModsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.confirm != nil {
+			pending := m.confirm
+			m.confirm = nil
+			if msg.String() == "y" {
+				m.uninstall(pending.containers, true)
+			} else {
+				m.status = "Uninstall cancelled"
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+
+		case "backspace":
+			return m, func() tea.Msg { return ui.BackMsg{} }
+
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down":
+			if m.cursor < len(m.mods)-1 {
+				m.cursor++
+			}
+
+		case " ":
+			if len(m.mods) > 0 {
+				m.selected[m.cursor] = !m.selected[m.cursor]
+				if !m.selected[m.cursor] {
+					delete(m.selected, m.cursor)
+				}
+			}
+
+		case "enter":
+			m.toggleSelected()
+
+		case "d":
+			changed, err := SetAllModsEnabled(m.mods, false)
+			m.report("Disabled", changed, err)
+
+		case "e":
+			changed, err := SetAllModsEnabled(m.mods, true)
+			m.report("Enabled", changed, err)
+
+		case "u":
+			var containers []string
+			for _, i := range m.targets() {
+				containers = append(containers, m.mods[i].Name)
+			}
+			m.uninstall(containers, false)
+
+		case "U":
+			var containers []string
+			for _, mod := range m.mods {
+				containers = append(containers, mod.Name)
+			}
+			m.uninstall(containers, false)
+		}
+	}
+
+	return m, nil
+}
+
+// The selected mods, or the one under the cursor when none are selected
+func (m InstalledModsModel) targets() []int {
+	if len(m.mods) == 0 {
+		return nil
+	}
+	if len(m.selected) == 0 {
+		return []int{m.cursor}
+	}
+
+	var indexes []int
+	for i := range m.mods {
+		if m.selected[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// Flip the selected mods, or the one under the cursor when none are selected
+func (m *InstalledModsModel) toggleSelected() {
+	changed := 0
+	for _, i := range m.targets() {
+		if err := SetModEnabled(&m.mods[i], m.mods[i].Disabled); err != nil {
+			m.report("Toggled", changed, err)
+			return
+		}
+		changed++
+	}
+	m.selected = make(map[int]bool)
+	m.report("Toggled", changed, nil)
+}
+
+// Remove deployed files, asking first if any were modified since deployment
+func (m *InstalledModsModel) uninstall(containers []string, force bool) {
+	if len(containers) == 0 {
+		return
+	}
+
+	removed, modified, err := Uninstall(containers, force)
+	if len(modified) > 0 && !force {
+		m.confirm = &pendingUninstall{containers: containers, modified: modified}
+		m.err = nil
+		return
+	}
+
+	mods, listErr := ListInstalledMods()
+	if listErr == nil {
+		m.mods = mods
+	}
+	m.cursor = max(0, min(m.cursor, len(m.mods)-1))
+	m.selected = make(map[int]bool)
+	m.err = err
+	m.status = fmt.Sprintf("Uninstalled %d mod(s), removed %d file(s)", len(containers), removed)
+}
+
+func (m *InstalledModsModel) report(action string, changed int, err error) {
+	m.err = err
+	m.status = fmt.Sprintf("%s %d mod(s)", action, changed)
+}
+
+func (m InstalledModsModel) View() string {
+	s := ui.TitleStyle.Render("Installed Mods") + "\n"
+	s += ui.InfoStyle.Render("Disabled mods are moved to "+DisabledDir()) + "\n\n"
+
+	if len(m.mods) == 0 && m.err == nil {
+		s += ui.InfoStyle.Render("No toolkit-built mods are installed.") + "\n"
+	}
+
+	for i, mod := range m.mods {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		checkbox := "   "
+		if m.selected[i] {
+			checkbox = " X "
+		}
+
+		state := ui.SuccessStyle.Render("enabled ")
+		if mod.Disabled {
+			state = ui.ErrorStyle.Render("disabled")
+		}
+
+		line := fmt.Sprintf("%s [%s] %s", cursor, checkbox, mod.Name)
+		detail := ui.InfoStyle.Render(fmt.Sprintf("  %d file(s), %s • from %s", len(mod.Files), utils.FormatSize(mod.Size), mod.Source))
+		if m.cursor == i {
+			s += ui.SelectedStyle.Render(line) + "  " + state + detail + "\n"
+		} else {
+			s += ui.NormalStyle.Render(line) + "  " + state + detail + "\n"
+		}
+	}
+
+	if m.confirm != nil {
+		s += "\n" + ui.ErrorStyle.Render(fmt.Sprintf("⚠ %d file(s) changed since the toolkit deployed them:", len(m.confirm.modified))) + "\n"
+		for _, name := range m.confirm.modified {
+			s += ui.InfoStyle.Render("    "+name) + "\n"
+		}
+		s += "\n" + ui.NormalStyle.Render("Remove them anyway? Y: Yes • Any other key: Cancel")
+		return s
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	} else if m.status != "" {
+		s += "\n" + ui.SuccessStyle.Render("✓ "+m.status) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("Space: Select • Enter: Enable/disable • D: Disable all • E: Enable all • U: Uninstall • Shift+U: Uninstall all • Backspace: Back • ESC: Quit")
+	return s
+}
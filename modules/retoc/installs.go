@@ -0,0 +1,259 @@
+package retoc
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
+)
+
+// retoc build installed side by side with others, under retoc/versions/<name>
+type RetocInstall struct {
+	Name       string `json:"name"`
+	Dir        string `json:"dir"`
+	Executable string `json:"executable,omitempty"` // Empty if the folder holds no retoc binary
+}
+
+type RetocInstalledMsg struct {
+	Install RetocInstall
+	Err     error
+}
+
+func retocVersionsDir() string {
+	return filepath.Join(config.Current.RetocDir, "versions")
+}
+
+// List the managed retoc installs by name
+func ListRetocInstalls() ([]RetocInstall, error) {
+	entries, err := os.ReadDir(retocVersionsDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var installs []RetocInstall
+	for _, entry := range entries {
+		// Dot folders are installs still being unpacked
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		dir := filepath.Join(retocVersionsDir(), entry.Name())
+		installs = append(installs, RetocInstall{
+			Name:       entry.Name(),
+			Dir:        dir,
+			Executable: installExecutable(dir),
+		})
+	}
+
+	sort.Slice(installs, func(i, j int) bool { return installs[i].Name < installs[j].Name })
+	return installs, nil
+}
+
+// Look up a managed install by name
+func FindRetocInstall(name string) (RetocInstall, error) {
+	dir := filepath.Join(retocVersionsDir(), name)
+	if err := validateInstallName(name); err != nil {
+		return RetocInstall{}, err
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return RetocInstall{}, fmt.Errorf("retoc %s is not installed", name)
+	}
+
+	install := RetocInstall{Name: name, Dir: dir, Executable: installExecutable(dir)}
+	if install.Executable == "" {
+		return install, fmt.Errorf("retoc %s has no %s in %s", name, retocBinaryName(), dir)
+	}
+	return install, nil
+}
+
+// The retoc binary in an install folder, either at its top level or in a
+// single folder below it as release zips are often laid out
+func installExecutable(dir string) string {
+	path := filepath.Join(dir, retocBinaryName())
+	if _, err := os.Stat(path); err == nil {
+		return path
+	}
+
+	nested, _ := filepath.Glob(filepath.Join(dir, "*", retocBinaryName()))
+	if len(nested) == 1 {
+		return nested[0]
+	}
+	return ""
+}
+
+func validateInstallName(name string) error {
+	if name == "" || name == "." || name == ".." || strings.HasPrefix(name, ".") || strings.ContainsAny(name, `/\:`) {
+		return fmt.Errorf("invalid retoc version name: %q", name)
+	}
+	return nil
+}
+
+// Install a retoc build from a zip or a folder. Without a name the install
+// is named after the version retoc reports, if it reports one.
+func InstallRetoc(ctx context.Context, source, name string) (RetocInstall, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return RetocInstall{}, err
+	}
+
+	if err := os.MkdirAll(retocVersionsDir(), 0755); err != nil {
+		return RetocInstall{}, err
+	}
+	staging, err := os.MkdirTemp(retocVersionsDir(), ".install-*")
+	if err != nil {
+		return RetocInstall{}, err
+	}
+	defer os.RemoveAll(staging)
+
+	switch {
+	case info.IsDir():
+		err = utils.CopyDir(source, staging)
+	case strings.EqualFold(filepath.Ext(source), ".zip"):
+		err = extractZip(source, staging)
+	default:
+		err = errors.New("expected a .zip file or a folder")
+	}
+	if err != nil {
+		return RetocInstall{}, fmt.Errorf("install from %s: %w", source, err)
+	}
+
+	executable := installExecutable(staging)
+	if executable == "" {
+		return RetocInstall{}, fmt.Errorf("no %s found in %s", retocBinaryName(), source)
+	}
+	if runtime.GOOS != "windows" {
+		if err := os.Chmod(executable, 0755); err != nil {
+			return RetocInstall{}, err
+		}
+	}
+
+	// Released builds have no --version flag, so --help proves the binary runs
+	if _, err := probeRetoc(ctx, executable, "--help"); err != nil {
+		return RetocInstall{}, fmt.Errorf("retoc --help failed: %w", err)
+	}
+	if name == "" {
+		if output, err := probeRetoc(ctx, executable, "--version"); err == nil {
+			name = parseRetocVersion(output)
+		}
+		if name == "" {
+			return RetocInstall{}, errors.New("this retoc doesn't report its version; give the install a name")
+		}
+	}
+	if err := validateInstallName(name); err != nil {
+		return RetocInstall{}, err
+	}
+
+	dir := filepath.Join(retocVersionsDir(), name)
+	if _, err := os.Stat(dir); err == nil {
+		return RetocInstall{}, fmt.Errorf("retoc %s is already installed", name)
+	}
+	if err := os.Rename(staging, dir); err != nil {
+		return RetocInstall{}, err
+	}
+
+	return RetocInstall{Name: name, Dir: dir, Executable: installExecutable(dir)}, nil
+}
+
+// Install in the background
+func InstallRetocAsync(ctx context.Context, source, name string) tea.Cmd {
+	return func() tea.Msg {
+		install, err := InstallRetoc(ctx, source, name)
+		return RetocInstalledMsg{Install: install, Err: err}
+	}
+}
+
+// Delete a managed install unless a profile is pinned to it
+func RemoveRetocInstall(name string) error {
+	install, err := FindRetocInstall(name)
+	if err != nil && install.Dir == "" {
+		return err
+	}
+	for _, p := range config.Current.Profiles {
+		if p.RetocVersion == name {
+			return fmt.Errorf("retoc %s is pinned by profile %s", name, p.Name)
+		}
+	}
+	return os.RemoveAll(install.Dir)
+}
+
+// Make the active profile use a managed install; an empty name goes back
+// to the default retoc
+func PinRetoc(name string) error {
+	if name != "" {
+		if _, err := FindRetocInstall(name); err != nil {
+			return err
+		}
+	}
+	config.Active().RetocVersion = name
+	return config.SaveConfig()
+}
+
+// Version number from retoc --version output, e.g. "retoc 0.1.2"
+func parseRetocVersion(output string) string {
+	fields := strings.Fields(output)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
+
+func extractZip(src, dst string) error {
+	r, err := zip.OpenReader(src)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		target := filepath.Join(dst, filepath.FromSlash(f.Name))
+		// Entries must not escape the install folder
+		if !strings.HasPrefix(target, filepath.Clean(dst)+string(filepath.Separator)) {
+			return fmt.Errorf("zip entry outside the archive: %s", f.Name)
+		}
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(f *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	in, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, f.Mode().Perm()|0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
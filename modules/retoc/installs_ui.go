@@ -0,0 +1,249 @@
+package retoc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+// Text prompt shown while installing
+type installInput int
+
+const (
+	installNone installInput = iota
+	installSource
+	installName
+)
+
+type RetocVersionsModel struct {
+	installs   []RetocInstall
+	cursor     int // 0 is the default retoc, installs follow
+	input      installInput
+	source     string
+	installing bool
+	textInput  textinput.Model
+	status     string
+	err        error
+}
+
+func NewRetocVersionsModel() RetocVersionsModel {
+	ti := textinput.New()
+	ti.Width = 70
+
+	installs, err := ListRetocInstalls()
+	m := RetocVersionsModel{
+		installs:  installs,
+		textInput: ti,
+		err:       err,
+	}
+	for i, install := range installs {
+		if install.Name == config.Active().RetocVersion {
+			m.cursor = i + 1
+		}
+	}
+	return m
+}
+
+func (m RetocVersionsModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m RetocVersionsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.input != installNone {
+		return m.updateInput(msg)
+	}
+
+	switch msg := msg.(type) {
+	case RetocInstalledMsg:
+		m.installing = false
+		m.err = msg.Err
+		if msg.Err == nil {
+			m.status = fmt.Sprintf("Installed retoc %s", msg.Install.Name)
+			m.reload()
+		}
+
+	case tea.KeyMsg:
+		if m.installing {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+
+		case "backspace":
+			return m, func() tea.Msg { return ui.BackMsg{} }
+
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down":
+			if m.cursor < len(m.installs) {
+				m.cursor++
+			}
+
+		case "enter":
+			name := ""
+			if m.cursor > 0 {
+				name = m.installs[m.cursor-1].Name
+			}
+			m.err = PinRetoc(name)
+			if m.err == nil {
+				m.status = fmt.Sprintf("%s now uses %s", config.Active().Name, retocLabel(name))
+			}
+
+		case "i":
+			m.input = installSource
+			m.err = nil
+			m.textInput.Placeholder = "Path to a retoc .zip or folder..."
+			m.textInput.SetValue("")
+			m.textInput.Focus()
+			return m, textinput.Blink
+
+		case "d":
+			if m.cursor == 0 {
+				return m, nil
+			}
+			name := m.installs[m.cursor-1].Name
+			m.err = RemoveRetocInstall(name)
+			if m.err == nil {
+				m.status = fmt.Sprintf("Removed retoc %s", name)
+				m.reload()
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (m RetocVersionsModel) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+
+		case tea.KeyEsc:
+			m.input = installNone
+			m.textInput.Blur()
+			return m, nil
+
+		case tea.KeyEnter:
+			if m.input == installSource {
+				source, err := config.NormalizePath(m.textInput.Value())
+				if err != nil {
+					m.err = err
+					return m, nil
+				}
+				m.source = source
+				m.input = installName
+				m.err = nil
+				m.textInput.Placeholder = "Name, e.g. nightly (empty to use the version retoc reports)..."
+				m.textInput.SetValue("")
+				return m, nil
+			}
+
+			m.input = installNone
+			m.installing = true
+			m.textInput.Blur()
+			m.status = ""
+			return m, InstallRetocAsync(context.Background(), m.source, strings.TrimSpace(m.textInput.Value()))
+		}
+	}
+
+	var cmd tea.Cmd
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+func (m *RetocVersionsModel) reload() {
+	installs, err := ListRetocInstalls()
+	if err != nil {
+		m.err = err
+		return
+	}
+	m.installs = installs
+	m.cursor = min(m.cursor, len(m.installs))
+}
+
+func retocLabel(name string) string {
+	if name == "" {
+		return "the default retoc"
+	}
+	return "retoc " + name
+}
+
+func (m RetocVersionsModel) View() string {
+	s := ui.TitleStyle.Render("Retoc Versions") + "\n"
+	s += ui.InfoStyle.Render("Installed under "+retocVersionsDir()+" • Profile: "+config.Active().Name) + "\n\n"
+
+	pinned := config.Active().RetocVersion
+	rows := []string{"Default (retoc_path, retoc folder or PATH)"}
+	details := []string{""}
+	for _, install := range m.installs {
+		rows = append(rows, install.Name)
+		detail := install.Executable
+		if detail == "" {
+			detail = "no " + retocBinaryName() + " found"
+		}
+		details = append(details, detail)
+	}
+
+	for i, row := range rows {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		inUse := (i == 0 && pinned == "") || (i > 0 && m.installs[i-1].Name == pinned)
+		line := fmt.Sprintf("%s %s", cursor, row)
+		if inUse {
+			line += " (in use)"
+		}
+
+		if m.cursor == i {
+			s += ui.SelectedStyle.Render(line)
+		} else {
+			s += ui.NormalStyle.Render(line)
+		}
+		if details[i] != "" {
+			s += ui.InfoStyle.Render("  " + details[i])
+		}
+		s += "\n"
+	}
+
+	switch m.input {
+	case installSource:
+		s += "\n" + ui.NormalStyle.Render("Install retoc from:") + "\n"
+		s += m.textInput.View() + "\n"
+	case installName:
+		s += "\n" + ui.NormalStyle.Render("Name for the install from "+m.source+":") + "\n"
+		s += m.textInput.View() + "\n"
+	}
+	if m.installing {
+		s += "\n" + ui.BuildingStyle.Render("Installing...") + "\n"
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	} else if m.status != "" {
+		s += "\n" + ui.SuccessStyle.Render("✓ "+m.status) + "\n"
+	}
+
+	if m.input != installNone {
+		s += "\n" + ui.InfoStyle.Render("Enter: Continue • ESC: Cancel")
+	} else {
+		s += "\n" + ui.InfoStyle.Render("↑/↓: Navigate • Enter: Use for this profile • I: Install • D: Remove • Backspace: Back • ESC: Quit")
+	}
+	return s
+}
@@ -0,0 +1,181 @@
+package retoc
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
+)
+
+// Container name a mod is deployed under. Mods in the profile's load order
+// become z_<Name>_<NNNN>_P, numbered from lowest to highest priority; others
+// keep their folder name.
+func OutputName(mod Mod) string {
+	if i := slices.Index(config.Active().LoadOrder, mod.Name); i >= 0 {
+		return loadOrderName(mod.Name, i)
+	}
+	return mod.Name
+}
+
+func loadOrderName(folderName string, index int) string {
+	return fmt.Sprintf("z_%s_%04d_P", utils.StripLoadOrder(folderName), index+1)
+}
+
+// Sort mods by the profile's load order; mods not in it keep their order at the end
+func SortByLoadOrder(mods []Mod) []Mod {
+	order := config.Active().LoadOrder
+	sorted := slices.Clone(mods)
+	slices.SortStableFunc(sorted, func(a, b Mod) int {
+		ia, ib := slices.Index(order, a.Name), slices.Index(order, b.Name)
+		if ia < 0 {
+			ia = len(order)
+		}
+		if ib < 0 {
+			ib = len(order)
+		}
+		return ia - ib
+	})
+	return sorted
+}
+
+// Load order that saving mods in this order gives. Entries for mods that
+// weren't discovered, e.g. because the mods folder moved, keep their place so
+// their deployed files keep their names; mods fill the other places in order.
+func mergeLoadOrder(mods []Mod) []string {
+	old := config.Active().LoadOrder
+	given := make(map[string]bool)
+	for _, mod := range mods {
+		given[mod.Name] = true
+	}
+
+	var order []string
+	next := 0
+	for i := 0; i < len(old) || next < len(mods); i++ {
+		switch {
+		case i < len(old) && !given[old[i]]:
+			order = append(order, old[i])
+		case next < len(mods):
+			order = append(order, mods[next].Name)
+			next++
+		}
+	}
+	return order
+}
+
+// Save mods as the new load order, lowest priority first, and rename any
+// outputs already in the Paks directory to their new names. The order is only
+// changed once every file has been renamed.
+func SetLoadOrder(mods []Mod) (int, error) {
+	order := mergeLoadOrder(mods)
+	renames := make(map[string]string)
+	for _, mod := range mods {
+		renames[OutputName(mod)] = loadOrderName(mod.Name, slices.Index(order, mod.Name))
+	}
+
+	names, err := renameOutputs(renames)
+	if err != nil {
+		return 0, err
+	}
+	config.Active().LoadOrder = order
+
+	if err := recordRenames(renames, names); err != nil {
+		return len(names), err
+	}
+	if err := config.SaveConfig(); err != nil {
+		return len(names), fmt.Errorf("failed to save config: %w", err)
+	}
+	return len(names), nil
+}
+
+// Rename deployed containers, enabled or disabled, from old to new base
+// names, and return the new name of each renamed file. Files are moved aside
+// first so swapping two names can't overwrite either one, and if any rename
+// fails the ones already made are undone.
+func renameOutputs(renames map[string]string) (map[string]string, error) {
+	type move struct{ src, tmp, dst string }
+	var moves []move
+	placed := 0
+	names := make(map[string]string)
+
+	undo := func(err error) (map[string]string, error) {
+		failed := false
+		for _, mv := range moves[:placed] {
+			if os.Rename(mv.dst, mv.tmp) != nil {
+				failed = true
+			}
+		}
+		for _, mv := range moves {
+			if os.Rename(mv.tmp, mv.src) != nil {
+				failed = true
+			}
+		}
+		if failed {
+			return nil, fmt.Errorf("%w; some files couldn't be renamed back and are left with a .reorder extension", err)
+		}
+		return nil, err
+	}
+
+	for _, dir := range []string{config.Active().PakDir, DisabledDir()} {
+		for from, to := range renames {
+			if from == to {
+				continue
+			}
+			for _, ext := range containerExts {
+				src := filepath.Join(dir, from+ext)
+				if _, err := os.Stat(src); err != nil {
+					continue
+				}
+				tmp := src + ".reorder"
+				if err := os.Rename(src, tmp); err != nil {
+					return undo(fmt.Errorf("rename %s: %w", filepath.Base(src), err))
+				}
+				moves = append(moves, move{src, tmp, filepath.Join(dir, to+ext)})
+				names[from+ext] = to + ext
+			}
+		}
+	}
+
+	for _, mv := range moves {
+		if err := os.Rename(mv.tmp, mv.dst); err != nil {
+			return undo(fmt.Errorf("rename %s: %w", filepath.Base(mv.dst), err))
+		}
+		placed++
+	}
+	return names, nil
+}
+
+// Keep the deployment manifest and build cache pointing at renamed files
+func recordRenames(renames, names map[string]string) error {
+	if manifest, err := loadManifest(); err == nil {
+		for from, to := range renames {
+			deployment, ok := manifest.Deployments[from]
+			if !ok || from == to {
+				continue
+			}
+			for i, f := range deployment.Files {
+				if renamed, ok := names[f.Name]; ok {
+					deployment.Files[i].Name = renamed
+				}
+			}
+			delete(manifest.Deployments, from)
+			manifest.Deployments[to] = deployment
+		}
+		if err := manifest.save(); err != nil {
+			return err
+		}
+	}
+
+	cache := loadBuildCache()
+	for path, entry := range cache.Entries {
+		for i, name := range entry.Outputs {
+			if renamed, ok := names[name]; ok {
+				entry.Outputs[i] = renamed
+			}
+		}
+		cache.Entries[path] = entry
+	}
+	return cache.save()
+}
@@ -0,0 +1,60 @@
+package retoc
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// Deploy a placeholder .utoc under each name
+func deployNames(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name+".utoc"), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSetLoadOrderKeepsUndiscoveredMods(t *testing.T) {
+	profile := useTestProfile(t, &fakeRunner{})
+	profile.LoadOrder = []string{"A", "Gone", "B"}
+	deployNames(t, profile.PakDir, "z_A_0001_P", "z_Gone_0002_P", "z_B_0003_P")
+
+	if _, err := SetLoadOrder([]Mod{{Name: "B"}, {Name: "A"}}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"B", "Gone", "A"}; !slices.Equal(profile.LoadOrder, want) {
+		t.Errorf("load order = %v, want %v", profile.LoadOrder, want)
+	}
+	want := []string{"z_A_0003_P.utoc", "z_B_0001_P.utoc", "z_Gone_0002_P.utoc"}
+	if got := dirFiles(t, profile.PakDir); !slices.Equal(got, want) {
+		t.Errorf("Paks = %v, want %v", got, want)
+	}
+}
+
+func TestSetLoadOrderUndoesFailedRenames(t *testing.T) {
+	profile := useTestProfile(t, &fakeRunner{})
+	profile.LoadOrder = []string{"A", "B", "C"}
+	deployNames(t, profile.PakDir, "z_A_0001_P", "z_B_0002_P", "z_C_0003_P")
+
+	// A folder in the way of B's new name makes that one rename fail
+	if err := os.MkdirAll(filepath.Join(profile.PakDir, "z_B_0001_P.utoc", "in-the-way"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SetLoadOrder([]Mod{{Name: "B"}, {Name: "C"}, {Name: "A"}}); err == nil {
+		t.Fatal("reordered despite a failed rename")
+	}
+	if want := []string{"A", "B", "C"}; !slices.Equal(profile.LoadOrder, want) {
+		t.Errorf("load order = %v, want it unchanged %v", profile.LoadOrder, want)
+	}
+	want := []string{"z_A_0001_P.utoc", "z_B_0001_P.utoc", "z_B_0002_P.utoc", "z_C_0003_P.utoc"}
+	if got := dirFiles(t, profile.PakDir); !slices.Equal(got, want) {
+		t.Errorf("Paks = %v, want %v", got, want)
+	}
+	if data, err := os.ReadFile(filepath.Join(profile.PakDir, "z_B_0002_P.utoc")); err != nil || string(data) != "z_B_0002_P" {
+		t.Errorf("z_B_0002_P.utoc = %q, %v; want B's own file back", data, err)
+	}
+}
@@ -0,0 +1,115 @@
+package retoc
+
+import (
+	"fmt"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+type LoadOrderModel struct {
+	mods    []Mod
+	cursor  int
+	changed bool
+	status  string
+	err     error
+}
+
+func NewLoadOrderModel() LoadOrderModel {
+	mods, err := DiscoverMods()
+	return LoadOrderModel{
+		mods: SortByLoadOrder(mods),
+		err:  err,
+	}
+}
+
+func (m LoadOrderModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m LoadOrderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+
+		case "backspace":
+			return m, func() tea.Msg { return ui.BackMsg{} }
+
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down":
+			if m.cursor < len(m.mods)-1 {
+				m.cursor++
+			}
+
+		case "shift+up", "K":
+			if m.cursor > 0 {
+				m.mods[m.cursor-1], m.mods[m.cursor] = m.mods[m.cursor], m.mods[m.cursor-1]
+				m.cursor--
+				m.changed = true
+			}
+
+		case "shift+down", "J":
+			if m.cursor < len(m.mods)-1 {
+				m.mods[m.cursor+1], m.mods[m.cursor] = m.mods[m.cursor], m.mods[m.cursor+1]
+				m.cursor++
+				m.changed = true
+			}
+
+		case "enter", "s":
+			if len(m.mods) == 0 {
+				return m, nil
+			}
+			renamed, err := SetLoadOrder(m.mods)
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.changed = false
+			m.status = fmt.Sprintf("✓ Load order saved, %d deployed file(s) renamed", renamed)
+		}
+	}
+
+	return m, nil
+}
+
+func (m LoadOrderModel) View() string {
+	s := ui.TitleStyle.Render("Load Order") + "\n"
+	s += ui.InfoStyle.Render("Lowest priority first; mods further down override those above them") + "\n\n"
+
+	order := mergeLoadOrder(m.mods)
+	for i, mod := range m.mods {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		line := fmt.Sprintf("%s %2d. %s", cursor, i+1, mod.DisplayName)
+		if m.cursor == i {
+			s += ui.SelectedStyle.Render(line)
+		} else {
+			s += ui.NormalStyle.Render(line)
+		}
+		// Name the mod will have once this order is saved
+		s += ui.InfoStyle.Render("  → "+loadOrderName(mod.Name, slices.Index(order, mod.Name))) + "\n"
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	} else if m.changed {
+		s += "\n" + ui.InfoStyle.Render("Unsaved changes") + "\n"
+	} else if m.status != "" {
+		s += "\n" + ui.SuccessStyle.Render(m.status) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("↑/↓: Navigate • Shift+↑/↓ (K/J): Move • Enter: Save and rename • Backspace: Back • ESC: Quit")
+	return s
+}
@@ -0,0 +1,245 @@
+package retoc
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// File copied into the Paks directory
+type DeployedFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Container the toolkit deployed and the mod it was built from
+type Deployment struct {
+	Mod        string         `json:"mod"` // Mod folder name
+	Files      []DeployedFile `json:"files"`
+	DeployedAt time.Time      `json:"deployed_at"`
+}
+
+// Everything the toolkit deployed for one game profile, keyed by container name
+type deployManifest struct {
+	mu          sync.Mutex
+	Deployments map[string]Deployment `json:"deployments"`
+}
+
+func manifestPath() (string, error) {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dataDir, "deployments", profileFileName()+".json"), nil
+}
+
+// Active profile name made safe for use in file names, since profile names are free text
+func profileFileName() string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, config.Active().Name)
+}
+
+// Load the active profile's manifest. The first time, outputs the build cache
+// already knows about are adopted as they are on disk now.
+func loadManifest() (*deployManifest, error) {
+	manifest := &deployManifest{Deployments: make(map[string]Deployment)}
+
+	path, err := manifestPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		manifest.adoptBuildCache()
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("deployment manifest is corrupt: %w", err)
+	}
+	if manifest.Deployments == nil {
+		manifest.Deployments = make(map[string]Deployment)
+	}
+	return manifest, nil
+}
+
+// Save the manifest to disk
+func (d *deployManifest) save() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	path, err := manifestPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Record the files just deployed for mod
+func (d *deployManifest) record(mod Mod, outputs []OutputFile) error {
+	deployment := Deployment{
+		Mod:        mod.Name,
+		DeployedAt: time.Now(),
+	}
+	for _, out := range outputs {
+		sum, err := hashFile(filepath.Join(config.Active().PakDir, out.Name))
+		if err != nil {
+			return err
+		}
+		deployment.Files = append(deployment.Files, DeployedFile{Name: out.Name, Size: out.Size, SHA256: sum})
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.Deployments[OutputName(mod)] = deployment
+	return nil
+}
+
+// Track outputs deployed before manifests existed
+func (d *deployManifest) adoptBuildCache() {
+	cache := loadBuildCache()
+	for modPath, entry := range cache.Entries {
+		for _, name := range entry.Outputs {
+			path, ok := deployedPath(name)
+			if !ok {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			sum, err := hashFile(path)
+			if err != nil {
+				continue
+			}
+
+			container := strings.TrimSuffix(name, filepath.Ext(name))
+			deployment := d.Deployments[container]
+			deployment.Mod = filepath.Base(modPath)
+			deployment.DeployedAt = entry.BuiltAt
+			deployment.Files = append(deployment.Files, DeployedFile{Name: name, Size: info.Size(), SHA256: sum})
+			d.Deployments[container] = deployment
+		}
+	}
+}
+
+// Where a deployed file currently lives: the Paks directory, or the disabled folder
+func deployedPath(name string) (string, bool) {
+	for _, dir := range []string{config.Active().PakDir, DisabledDir()} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// Files of a deployment whose contents no longer match what was deployed
+func (d Deployment) modifiedFiles() []string {
+	var modified []string
+	for _, f := range d.Files {
+		path, ok := deployedPath(f.Name)
+		if !ok {
+			continue
+		}
+		if sum, err := hashFile(path); err != nil || sum != f.SHA256 {
+			modified = append(modified, f.Name)
+		}
+	}
+	return modified
+}
+
+// Remove the files of the given deployments, enabled or disabled. If any of
+// them were modified since deployment nothing is removed unless force is set,
+// and the modified file names are returned.
+func Uninstall(containers []string, force bool) (removed int, modified []string, err error) {
+	manifest, err := loadManifest()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	for _, container := range containers {
+		deployment, ok := manifest.Deployments[container]
+		if !ok {
+			return 0, nil, fmt.Errorf("%s was not deployed by the toolkit", container)
+		}
+		modified = append(modified, deployment.modifiedFiles()...)
+	}
+	if len(modified) > 0 && !force {
+		return 0, modified, fmt.Errorf("%d file(s) were modified after deployment", len(modified))
+	}
+
+	for _, container := range containers {
+		for _, f := range manifest.Deployments[container].Files {
+			for {
+				path, ok := deployedPath(f.Name)
+				if !ok {
+					break
+				}
+				if err := os.Remove(path); err != nil {
+					manifest.save()
+					return removed, modified, fmt.Errorf("remove %s: %w", f.Name, err)
+				}
+				removed++
+			}
+		}
+		delete(manifest.Deployments, container)
+	}
+
+	return removed, modified, manifest.save()
+}
+
+// Remove everything the toolkit deployed for the active profile
+func UninstallAll(force bool) (int, []string, error) {
+	manifest, err := loadManifest()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var containers []string
+	for container := range manifest.Deployments {
+		containers = append(containers, container)
+	}
+	return Uninstall(containers, force)
+}
+
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
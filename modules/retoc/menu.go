@@ -28,7 +28,7 @@ func NewRetocMenuModel() RetocMenuModel {
 			Description: "Build mods from modified UAsset/UEXP files into Zen pak format",
 			Handler: func() tea.Model {
 				// If paths are already configured, go directly to pack builder
-				if config.Current.ModsDir != "" && config.Current.PakDir != "" {
+				if config.Active().ModsDir != "" && config.Active().PakDir != "" && config.Active().EngineVersion != "" {
 					mods, err := DiscoverMods()
 					if err == nil && len(mods) > 0 {
 						return NewPackBuilderModel(mods)
@@ -45,6 +45,48 @@ func NewRetocMenuModel() RetocMenuModel {
 				return NewUnpackSetupModel()
 			},
 		},
+		{
+			Name:        "Browse Containers",
+			Description: "Explore the files inside the game's .utoc containers",
+			Handler: func() tea.Model {
+				return NewContainerBrowserModel()
+			},
+		},
+		{
+			Name:        "Installed Mods",
+			Description: "Enable or disable deployed mods without deleting them",
+			Handler: func() tea.Model {
+				return NewInstalledModsModel()
+			},
+		},
+		{
+			Name:        "Load Order",
+			Description: "Reorder mods to control which one wins when they override the same asset",
+			Handler: func() tea.Model {
+				return NewLoadOrderModel()
+			},
+		},
+		{
+			Name:        "Build History",
+			Description: "Browse past builds and the full retoc log of each mod",
+			Handler: func() tea.Model {
+				return NewBuildHistoryModel()
+			},
+		},
+		{
+			Name:        "Retoc Doctor",
+			Description: "Check that retoc is installed and working, and manage retoc versions per profile",
+			Handler: func() tea.Model {
+				return NewDoctorCheckModel()
+			},
+		},
+		{
+			Name:        "Switch Game Profile",
+			Description: "Choose which game's paths and settings to use",
+			Handler: func() tea.Model {
+				return NewProfileSelectModel()
+			},
+		},
 	}
 
 	return RetocMenuModel{
@@ -83,15 +125,13 @@ func (m RetocMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			// Switch to selected workflow
-			selectedWorkflow := m.workflows[m.cursor]
-			return selectedWorkflow.Handler(), nil
+			return m.open(m.workflows[m.cursor])
 
-		case "1", "2", "3", "4", "5":
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 			// Hotkey selection
 			idx := int(msg.String()[0] - '1')
 			if idx < len(m.workflows) {
-				selectedWorkflow := m.workflows[idx]
-				return selectedWorkflow.Handler(), nil
+				return m.open(m.workflows[idx])
 			}
 		}
 
@@ -102,9 +142,17 @@ func (m RetocMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Switch to a workflow's screen. Bubble Tea only calls Init on the program's
+// first model, so the screen's own start-up command is run here.
+func (m RetocMenuModel) open(workflow WorkflowOption) (tea.Model, tea.Cmd) {
+	next := workflow.Handler()
+	return next, next.Init()
+}
+
 // Render workflow selector
 func (m RetocMenuModel) View() string {
-	s := ui.TitleStyle.Render("Retoc - Zen Asset Packer/Unpacker") + "\n\n"
+	s := ui.TitleStyle.Render("Retoc - Zen Asset Packer/Unpacker") + "\n"
+	s += ui.InfoStyle.Render("Profile: "+config.Active().Name) + "\n\n"
 	s += ui.NormalStyle.Render("Select a workflow:") + "\n\n"
 
 	for i, workflow := range m.workflows {
@@ -0,0 +1,32 @@
+package retoc
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMenuStartsDoctorChecks(t *testing.T) {
+	useTestProfile(t, &fakeRunner{})
+
+	var model tea.Model = NewRetocMenuModel()
+	for model.(RetocMenuModel).workflows[model.(RetocMenuModel).cursor].Name != "Retoc Doctor" {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	model, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := model.(DoctorModel); !ok {
+		t.Fatalf("opened %T, want DoctorModel", model)
+	}
+	if cmd == nil {
+		t.Fatal("opening the doctor didn't start the checks")
+	}
+
+	msg := cmd()
+	if _, ok := msg.(DiagnosisMsg); !ok {
+		t.Fatalf("checks sent %T, want DiagnosisMsg", msg)
+	}
+	model, _ = model.Update(msg)
+	if doctor := model.(DoctorModel); doctor.checking || len(doctor.diagnosis.Checks) == 0 {
+		t.Errorf("doctor still checking after the diagnosis: %+v", doctor)
+	}
+}
@@ -2,14 +2,10 @@ package retoc
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
-	"sync"
 
 	tea "github.com/charmbracelet/bubbletea"
 
@@ -18,188 +14,196 @@ import (
 )
 
 // Execute retoc packing process
-func BuildMod(ctx context.Context, log *strings.Builder, mod Mod) error {
-	outUtoc := filepath.Join(filepath.Dir(mod.Path), mod.Name+".utoc")
+// Each line retoc prints is sent to events as it arrives (events may be nil).
+func BuildMod(ctx context.Context, mod Mod, events chan<- BuildOutputMsg) BuildResult {
+	result := newBuildResult(mod)
 
-	retocExe := filepath.Join(config.Current.RetocDir, "retoc.exe")
-	if runtime.GOOS != "windows" {
-		retocExe = filepath.Join(config.Current.RetocDir, "retoc")
+	var log strings.Builder
+	err := buildMod(ctx, &log, mod, events, &result)
+	result.finish(log.String(), err)
+
+	return result
+}
+
+func buildMod(ctx context.Context, log *strings.Builder, mod Mod, events chan<- BuildOutputMsg, result *BuildResult) error {
+	// retoc writes into a private staging directory so nothing lands next to
+	// the mod sources and parallel builds can't pick up each other's files
+	staging, err := os.MkdirTemp("", "tinkr-build-*")
+	if err != nil {
+		return fmt.Errorf("create staging directory: %w", err)
 	}
+	defer os.RemoveAll(staging)
+
+	outUtoc := filepath.Join(staging, OutputName(mod)+".utoc")
 
 	fmt.Fprintf(log, "  Folder: %s\n", mod.Name)
 	fmt.Fprintf(log, "  Output: %s\n", filepath.Base(outUtoc))
-
-	cmd := exec.CommandContext(ctx, retocExe, "to-zen", "--version", "UE5_4", "--", mod.Path, outUtoc)
-	cmd.Dir = config.Current.RetocDir
-
-	output, err := cmd.CombinedOutput()
+	fmt.Fprintf(log, "  Engine: %s\n", result.EngineVersion)
+
+	stdout, stderr, err := currentRunner().ToZen(ctx, mod.Path, outUtoc, result.EngineVersion, func(line string) {
+		sendBuildOutput(ctx, events, mod, line)
+	})
+	result.Stdout = stdout
+	result.Stderr = stderr
+	output := strings.TrimSpace(stdout + stderr)
 	if err != nil {
 		if ctx.Err() == context.Canceled {
-			return errors.New("build cancelled")
+			return errBuildCancelled
 		}
-		fmt.Fprintf(log, "  retoc error: %s\n", strings.TrimSpace(string(output)))
+		fmt.Fprintf(log, "  retoc error: %s\n", output)
 		return fmt.Errorf("retoc failed: %w", err)
 	}
 
 	if len(output) > 0 {
-		fmt.Fprintf(log, "  retoc: %s\n", strings.TrimSpace(string(output)))
+		fmt.Fprintf(log, "  retoc: %s\n", output)
 	}
 
-	pattern := filepath.Join(filepath.Dir(mod.Path), mod.Name+".*")
-	matches, err := filepath.Glob(pattern)
-	if err != nil {
-		return err
+	// Only the container files retoc is expected to produce are deployed
+	var matches []string
+	for _, ext := range containerExts {
+		path := filepath.Join(staging, OutputName(mod)+ext)
+		if _, err := os.Stat(path); err == nil {
+			matches = append(matches, path)
+		}
 	}
 
-	if len(matches) == 0 {
+	if _, err := os.Stat(outUtoc); err != nil {
 		return fmt.Errorf("no output files found")
 	}
 
+	if ctx.Err() != nil {
+		return errBuildCancelled
+	}
+
 	fmt.Fprintf(log, "  Found %d file(s) to copy\n", len(matches))
 
-	for _, srcPath := range matches {
-		fileName := filepath.Base(srcPath)
-		dstPath := filepath.Join(config.Current.PakDir, fileName)
+	backup, err := backupDeployed(mod)
+	if err != nil {
+		return fmt.Errorf("back up previous build: %w", err)
+	}
+	if len(backup.Files) > 0 {
+		fmt.Fprintf(log, "  Backed up %d file(s) of the previous build\n", len(backup.Files))
+	}
 
-		if err := utils.CopyFile(srcPath, dstPath); err != nil {
-			return fmt.Errorf("copy %s: %w", fileName, err)
+	// Copy everything in under temporary names first, so a failed or
+	// cancelled copy never leaves a mix of old and new files in Paks
+	var partials []string
+	abort := func(err error) error {
+		for _, path := range partials {
+			os.Remove(path)
+		}
+		if backup.Dir != "" {
+			os.RemoveAll(backup.Dir)
 		}
+		fmt.Fprintf(log, "  Deploy aborted, Paks left unchanged\n")
+		return err
+	}
 
-		if err := os.Remove(srcPath); err != nil {
-			return fmt.Errorf("remove %s: %w", fileName, err)
+	for _, srcPath := range matches {
+		if ctx.Err() != nil {
+			return abort(errBuildCancelled)
 		}
 
-		fmt.Fprintf(log, "  ✓ Copied %s → Paks/\n", fileName)
+		fileName := filepath.Base(srcPath)
+		partial := filepath.Join(config.Active().PakDir, fileName+".partial")
+		if err := utils.CopyFile(srcPath, partial); err != nil {
+			return abort(fmt.Errorf("copy %s: %w", fileName, err))
+		}
+		partials = append(partials, partial)
 	}
 
-	return nil
-}
-
-// Build all mods sequentially
-func BuildAllAsync(ctx context.Context, mods []Mod) tea.Cmd {
-	return func() tea.Msg {
-		var log strings.Builder
-		var builtMods []string
-		var failedMods []string
-
-		for i, mod := range mods {
-			fmt.Fprintf(&log, "==== [%d/%d] Building %s ====\n", i+1, len(mods), mod.DisplayName)
-			if err := BuildMod(ctx, &log, mod); err != nil {
-				failedMods = append(failedMods, mod.DisplayName)
-			} else {
-				builtMods = append(builtMods, mod.DisplayName)
+	if undone, err := swapInPartials(partials, backup); err != nil {
+		if !undone {
+			fmt.Fprintf(log, "  Deploy incomplete and couldn't be undone\n")
+			if backup.Dir != "" {
+				fmt.Fprintf(log, "  The previous build is kept in %s\n", backup.Dir)
 			}
-			log.WriteString("\n")
+			return err
 		}
+		return abort(err)
+	}
 
-		var displayLog strings.Builder
-		for _, modName := range builtMods {
-			displayLog.WriteString(fmt.Sprintf("✓ %s\n", modName))
-		}
-		for _, modName := range failedMods {
-			displayLog.WriteString(fmt.Sprintf("✗ %s\n", modName))
+	for _, srcPath := range matches {
+		fileName := filepath.Base(srcPath)
+		info, err := os.Stat(filepath.Join(config.Active().PakDir, fileName))
+		if err != nil {
+			return err
 		}
 
-		var finalErr error
-		if len(failedMods) > 0 {
-			finalErr = fmt.Errorf("%d mod(s) failed to build", len(failedMods))
-		}
+		// A fresh build is enabled; drop any copy parked in the disabled folder
+		os.Remove(filepath.Join(DisabledDir(), fileName))
 
-		return BuildCompleteMsg{
-			Log:        displayLog.String(),
-			Err:        finalErr,
-			BuiltMods:  builtMods,
-			FailedMods: failedMods,
+		result.Outputs = append(result.Outputs, OutputFile{Name: fileName, Size: info.Size()})
+		fmt.Fprintf(log, "  ✓ Copied %s (%s) → Paks/\n", fileName, utils.FormatSize(info.Size()))
+	}
+
+	// Pruned only now, so a failed deploy never costs an older rollback point
+	if err := pruneBackups(mod); err != nil {
+		fmt.Fprintf(log, "  Couldn't prune old backups: %v\n", err)
+	}
+
+	// A .sig left over from an earlier signed build would no longer match
+	for _, ext := range containerExts {
+		name := OutputName(mod) + ext
+		if _, err := os.Stat(filepath.Join(staging, name)); os.IsNotExist(err) {
+			os.Remove(filepath.Join(config.Active().PakDir, name))
 		}
 	}
+
+	return nil
+}
+
+// Build all mods on the worker pool, skipping unchanged ones unless force is set
+func BuildAllAsync(ctx context.Context, mods []Mod, events chan BuildOutputMsg, force bool) tea.Cmd {
+	return buildManyAsync(ctx, mods, events, !force)
 }
 
 // Build a single mod
-func BuildOneAsync(ctx context.Context, mod Mod) tea.Cmd {
+func BuildOneAsync(ctx context.Context, mod Mod, events chan BuildOutputMsg) tea.Cmd {
+	return buildManyAsync(ctx, []Mod{mod}, events, false)
+}
+
+// Build selected mods on the worker pool
+func BuildSelectedParallelAsync(ctx context.Context, selectedMods []Mod, events chan BuildOutputMsg) tea.Cmd {
+	return buildManyAsync(ctx, selectedMods, events, false)
+}
+
+func buildManyAsync(ctx context.Context, mods []Mod, events chan BuildOutputMsg, skipUnchanged bool) tea.Cmd {
 	return func() tea.Msg {
-		var log strings.Builder
+		defer close(events)
+		report := BuildMods(ctx, mods, events, skipUnchanged)
 
-		fmt.Fprintf(&log, "==== Building %s ====\n", mod.DisplayName)
-		err := BuildMod(ctx, &log, mod)
+		// The report is for later troubleshooting; a failed save shouldn't fail the build
+		RecordBuild(report)
 
-		var displayLog strings.Builder
-		if err != nil {
-			displayLog.WriteString(fmt.Sprintf("✗ %s\n", mod.DisplayName))
-			return BuildCompleteMsg{
-				Log:        displayLog.String(),
-				Err:        err,
-				BuiltMods:  []string{},
-				FailedMods: []string{mod.DisplayName},
+		// A single mod reports its own error; batches summarise
+		var finalErr error
+		if report.Cancelled() {
+			finalErr = fmt.Errorf("build cancelled: %d finished, %d stopped, %d not started",
+				report.Count(StatusBuilt)+report.Count(StatusUnchanged)+report.Count(StatusFailed),
+				report.Count(StatusCancelled), report.Count(StatusPending))
+		} else if failed := report.Count(StatusFailed); failed > 0 {
+			if len(mods) == 1 {
+				finalErr = report.Results[0].Err
+			} else {
+				finalErr = fmt.Errorf("%d mod(s) failed to build", failed)
 			}
 		}
 
-		displayLog.WriteString(fmt.Sprintf("✓ %s\n", mod.DisplayName))
 		return BuildCompleteMsg{
-			Log:        displayLog.String(),
-			Err:        nil,
-			BuiltMods:  []string{mod.DisplayName},
-			FailedMods: []string{},
+			Report: report,
+			Err:    finalErr,
 		}
 	}
 }
 
-// Build multiple mods in parallel
-func BuildSelectedParallelAsync(ctx context.Context, selectedMods []Mod) tea.Cmd {
+// Wait for the next line of build output
+func waitForBuildOutput(events <-chan BuildOutputMsg) tea.Cmd {
 	return func() tea.Msg {
-		var wg sync.WaitGroup
-		var mu sync.Mutex
-		var finalLog strings.Builder
-		var buildErrors []error
-		var builtMods []string
-		var failedMods []string
-
-		for _, mod := range selectedMods {
-			wg.Add(1)
-			go func(mod Mod) {
-				defer wg.Done()
-
-				var log strings.Builder
-				err := BuildMod(ctx, &log, mod)
-
-				mu.Lock()
-				finalLog.WriteString(fmt.Sprintf("==== %s ====\n", mod.DisplayName))
-				finalLog.WriteString(log.String())
-				finalLog.WriteString("\n")
-
-				if err != nil {
-					buildErrors = append(buildErrors, fmt.Errorf("%s: %w", mod.DisplayName, err))
-					failedMods = append(failedMods, mod.DisplayName)
-				} else {
-					builtMods = append(builtMods, mod.DisplayName)
-				}
-				mu.Unlock()
-			}(mod)
-		}
-
-		wg.Wait()
-
-		var displayLog strings.Builder
-		for _, modName := range builtMods {
-			displayLog.WriteString(fmt.Sprintf("✓ %s\n", modName))
-		}
-		for _, modName := range failedMods {
-			displayLog.WriteString(fmt.Sprintf("✗ %s\n", modName))
-		}
-
-		if len(buildErrors) > 0 {
-			return BuildCompleteMsg{
-				Log:        displayLog.String(),
-				Err:        fmt.Errorf("%d mod(s) failed to build", len(buildErrors)),
-				BuiltMods:  builtMods,
-				FailedMods: failedMods,
-			}
-		}
-
-		return BuildCompleteMsg{
-			Log:        displayLog.String(),
-			Err:        nil,
-			BuiltMods:  builtMods,
-			FailedMods: failedMods,
+		msg, ok := <-events
+		if !ok {
+			return nil
 		}
+		return msg
 	}
 }
@@ -0,0 +1,97 @@
+package retoc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+// Conflict report state for the Pak Builder
+type conflictState struct {
+	checking  bool
+	conflicts []Conflict
+	err       error
+	viewport  viewport.Model
+}
+
+// Scan every mod and installed z_ pak for overlapping assets
+func (m PackBuilderModel) startConflictCheck() (tea.Model, tea.Cmd) {
+	m.showConflicts = true
+	m.conflict = conflictState{
+		checking: true,
+		viewport: viewport.New(100, 20),
+	}
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	return m, FindConflictsAsync(m.ctx, m.mods)
+}
+
+func (m PackBuilderModel) updateConflicts(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			m.cancel()
+			return m, tea.Quit
+
+		case "esc", "backspace", "c":
+			m.cancel()
+			m.showConflicts = false
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.conflict.viewport, cmd = m.conflict.viewport.Update(msg)
+		return m, cmd
+
+	case ConflictsMsg:
+		m.conflict.checking = false
+		m.conflict.conflicts = msg.Conflicts
+		m.conflict.err = msg.Err
+		m.conflict.viewport.SetContent(renderConflicts(msg.Conflicts))
+		return m, nil
+	}
+	return m, nil
+}
+
+// One block per conflicting asset, listing containers in load order
+func renderConflicts(conflicts []Conflict) string {
+	var s strings.Builder
+	for _, c := range conflicts {
+		s.WriteString(ui.NormalStyle.Render(c.Asset) + "\n")
+		for _, source := range c.Sources {
+			name := source.Container
+			if source.Mod == "" {
+				name += " (installed)"
+			}
+			if source.Container == c.Winner {
+				s.WriteString(ui.SuccessStyle.Render("  ✓ "+name+" wins") + "\n")
+			} else {
+				s.WriteString(ui.InfoStyle.Render("  ✗ "+name+" overridden") + "\n")
+			}
+		}
+	}
+	return s.String()
+}
+
+func (m PackBuilderModel) conflictsView() string {
+	s := ui.TitleStyle.Render("Asset Conflicts") + "\n"
+
+	switch {
+	case m.conflict.checking:
+		s += "\n" + ui.BuildingStyle.Render("⚙️  Scanning mods and installed paks...") + "\n"
+	case m.conflict.err != nil:
+		s += "\n" + ui.ErrorStyle.Render("Error: ") + m.conflict.err.Error() + "\n"
+	case len(m.conflict.conflicts) == 0:
+		s += "\n" + ui.SuccessStyle.Render("✓ No asset is overridden by more than one mod or pak") + "\n"
+	default:
+		s += ui.InfoStyle.Render(fmt.Sprintf("%d asset(s) overridden more than once; the last to load wins", len(m.conflict.conflicts))) + "\n\n"
+		s += m.conflict.viewport.View() + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("↑/↓/PgUp/PgDn: Scroll • ESC: Back")
+	return s
+}
@@ -0,0 +1,241 @@
+package retoc
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+func TestBuildModDeploysContainers(t *testing.T) {
+	runner := &fakeRunner{zenExts: []string{".utoc", ".ucas", ".pak"}}
+	profile := useTestProfile(t, runner)
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	result := BuildMod(context.Background(), mod, nil)
+	if result.Status != StatusBuilt {
+		t.Fatalf("status = %s, want built: %v", result.Status, result.Err)
+	}
+
+	want := []string{"ModA.pak", "ModA.ucas", "ModA.utoc"}
+	if got := dirFiles(t, profile.PakDir); !slices.Equal(got, want) {
+		t.Errorf("Paks = %v, want %v", got, want)
+	}
+	if len(result.Outputs) != len(want) {
+		t.Errorf("outputs = %v, want %d files", result.Outputs, len(want))
+	}
+	if want := []string{"to-zen ModA UE5_4"}; !slices.Equal(runner.calls, want) {
+		t.Errorf("calls = %v, want %v", runner.calls, want)
+	}
+}
+
+func TestBuildModFailureLeavesPaksUnchanged(t *testing.T) {
+	runner := &fakeRunner{}
+	profile := useTestProfile(t, runner)
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	if result := BuildMod(context.Background(), mod, nil); result.Status != StatusBuilt {
+		t.Fatalf("first build: %v", result.Err)
+	}
+	before, err := os.ReadFile(filepath.Join(profile.PakDir, "ModA.utoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	runner.zenErr = errors.New("exit status 1")
+	result := BuildMod(context.Background(), mod, nil)
+	if result.Status != StatusFailed {
+		t.Fatalf("status = %s, want failed", result.Status)
+	}
+
+	after, err := os.ReadFile(filepath.Join(profile.PakDir, "ModA.utoc"))
+	if err != nil || string(after) != string(before) {
+		t.Errorf("deployed container changed after a failed build")
+	}
+	if got := dirFiles(t, profile.PakDir); !slices.Equal(got, []string{"ModA.ucas", "ModA.utoc"}) {
+		t.Errorf("Paks = %v", got)
+	}
+}
+
+func TestBuildModRemovesStaleContainers(t *testing.T) {
+	runner := &fakeRunner{zenExts: []string{".utoc", ".ucas", ".sig"}}
+	profile := useTestProfile(t, runner)
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	BuildMod(context.Background(), mod, nil)
+	runner.zenExts = []string{".utoc", ".ucas"}
+	if result := BuildMod(context.Background(), mod, nil); result.Status != StatusBuilt {
+		t.Fatalf("rebuild: %v", result.Err)
+	}
+
+	if got := dirFiles(t, profile.PakDir); slices.Contains(got, "ModA.sig") {
+		t.Errorf("stale signature still deployed: %v", got)
+	}
+	backups, err := ListBackups(mod)
+	if err != nil || len(backups) != 1 {
+		t.Fatalf("backups = %v, %v; want one", backups, err)
+	}
+}
+
+func TestBuildModsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runner := &fakeRunner{onZen: func(ctx context.Context) error {
+		cancel()
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	profile := useTestProfile(t, runner)
+	mods := []Mod{
+		writeMod(t, profile, "ModA", "Content/A.uasset"),
+		writeMod(t, profile, "ModB", "Content/B.uasset"),
+		writeMod(t, profile, "ModC", "Content/C.uasset"),
+	}
+
+	report := BuildMods(ctx, mods, nil, false)
+	if !report.Cancelled() {
+		t.Fatalf("report not cancelled: %+v", report.Results)
+	}
+	if n := report.Count(StatusBuilt); n != 0 {
+		t.Errorf("%d mod(s) built after cancellation", n)
+	}
+	if got := dirFiles(t, profile.PakDir); len(got) != 0 {
+		t.Errorf("Paks = %v, want empty", got)
+	}
+}
+
+func TestFailedDeployKeepsOldestBackup(t *testing.T) {
+	profile := useTestProfile(t, &fakeRunner{})
+	config.Current.MaxBackups = 2
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	for range 3 {
+		if result := BuildMod(context.Background(), mod, nil); result.Status != StatusBuilt {
+			t.Fatalf("build: %v", result.Err)
+		}
+	}
+	before, err := ListBackups(mod)
+	if err != nil || len(before) != 2 {
+		t.Fatalf("backups = %v, %v; want 2", before, err)
+	}
+
+	// A directory where the copy's temporary file goes makes the deploy fail
+	if err := os.MkdirAll(filepath.Join(profile.PakDir, "ModA.utoc.partial.tmp"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if result := BuildMod(context.Background(), mod, nil); result.Status != StatusFailed {
+		t.Fatalf("status = %s, want failed", result.Status)
+	}
+
+	after, err := ListBackups(mod)
+	if err != nil || len(after) != 2 || after[1].Dir != before[1].Dir {
+		t.Errorf("backups after a failed deploy = %v, want %v", after, before)
+	}
+}
+
+func TestRollbackCanBeUndone(t *testing.T) {
+	runner := &fakeRunner{}
+	profile := useTestProfile(t, runner)
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	build := func() {
+		t.Helper()
+		if result := BuildMod(context.Background(), mod, nil); result.Status != StatusBuilt {
+			t.Fatalf("build: %v", result.Err)
+		}
+	}
+	build()
+	runner.zenExts = []string{".utoc", ".ucas", ".pak"}
+	build()
+
+	rollback := func(want ...string) {
+		t.Helper()
+		if _, err := RollbackMod(mod); err != nil {
+			t.Fatal(err)
+		}
+		if got := dirFiles(t, profile.PakDir); !slices.Equal(got, want) {
+			t.Errorf("Paks after rollback = %v, want %v", got, want)
+		}
+	}
+	rollback("ModA.ucas", "ModA.utoc")
+	rollback("ModA.pak", "ModA.ucas", "ModA.utoc")
+
+	if backups, err := ListBackups(mod); err != nil || len(backups) != 1 {
+		t.Errorf("backups = %v, %v; want 1", backups, err)
+	}
+}
+
+func TestBackupsInTheSameMillisecondKeepSeparateFolders(t *testing.T) {
+	profile := useTestProfile(t, &fakeRunner{})
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	root, err := backupRoot(mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := time.Date(2026, 1, 2, 3, 4, 5, 6e6, time.Local)
+	var dirs []string
+	for range 3 {
+		dir, err := newBackupDir(root, created)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dirs = append(dirs, dir)
+	}
+
+	backups, err := ListBackups(mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, b := range backups {
+		got = append(got, b.Dir)
+	}
+	if want := []string{dirs[2], dirs[1], dirs[0]}; !slices.Equal(got, want) {
+		t.Errorf("backups = %v, want newest first %v", got, want)
+	}
+}
+
+func TestFailedRenamePutsBackReplacedFiles(t *testing.T) {
+	profile := useTestProfile(t, &fakeRunner{})
+	mod := writeMod(t, profile, "ModA", "Content/Weapons/Axe.uasset")
+
+	live := func(name string) string { return filepath.Join(profile.PakDir, name) }
+	if err := os.WriteFile(live("ModA.utoc"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	previous, err := backupDeployed(mod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// .utoc replaces the old file and .ucas is new, then the .pak can't be
+	// renamed because a folder is in its way
+	var partials []string
+	for _, name := range []string{"ModA.utoc", "ModA.ucas", "ModA.pak"} {
+		if err := os.WriteFile(live(name+".partial"), []byte("new"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		partials = append(partials, live(name+".partial"))
+	}
+	if err := os.MkdirAll(filepath.Join(live("ModA.pak"), "in-the-way"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	undone, err := swapInPartials(partials, previous)
+	if err == nil || !undone {
+		t.Fatalf("swap = %v, %v; want an error that was undone", undone, err)
+	}
+	if data, err := os.ReadFile(live("ModA.utoc")); err != nil || string(data) != "old" {
+		t.Errorf("ModA.utoc = %q, %v; want the old build back", data, err)
+	}
+	if got, want := dirFiles(t, profile.PakDir), []string{"ModA.pak", "ModA.utoc"}; !slices.Equal(got, want) {
+		t.Errorf("Paks = %v, want %v", got, want)
+	}
+}
@@ -3,36 +3,60 @@ package retoc
 import (
 	"context"
 	"fmt"
-	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/progress"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
 	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/utils"
 )
 
 type PackBuilderModel struct {
-	mods         []Mod
-	cursor       int
-	selected     map[int]bool
-	building     bool
-	buildStart   time.Time
-	log          string
-	err          error
-	ctx          context.Context
-	cancel       context.CancelFunc
-	startTime    time.Time
-	currentTask  string
-	parallelMode bool
+	mods          []Mod
+	cursor        int
+	selected      map[int]bool
+	building      bool
+	buildStart    time.Time
+	results       []BuildResult
+	err           error
+	ctx           context.Context
+	cancel        context.CancelFunc
+	startTime     time.Time
+	currentTask   string
+	buildMods     []Mod
+	events        chan BuildOutputMsg
+	started       map[string]bool
+	progress      map[string]modProgress
+	progressBar   progress.Model
+	logTail       []string
+	watching      bool
+	watch         watchState
+	showConflicts bool
+	conflict      conflictState
+	notice        string
+	cancelling    bool
+	quitting      bool
 }
 
-type BackMsg struct{}
+// Latest retoc progress for a mod being built
+type modProgress struct {
+	done  int
+	total int
+}
+
+// Number of retoc output lines kept for the building view
+const logTailSize = 12
+
+type buildTickMsg time.Time
 
 func NewPackBuilderModel(mods []Mod) PackBuilderModel {
 	return PackBuilderModel{
-		mods:     mods,
-		cursor:   0,
-		selected: make(map[int]bool),
+		mods:        mods,
+		cursor:      0,
+		selected:    make(map[int]bool),
+		progressBar: progress.New(progress.WithDefaultGradient(), progress.WithWidth(40)),
 	}
 }
 
@@ -41,21 +65,37 @@ func (m PackBuilderModel) Init() tea.Cmd {
 }
 
 func (m PackBuilderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.watching {
+		switch msg.(type) {
+		case tea.KeyMsg, watchTickMsg, watchScanMsg, BuildCompleteMsg:
+			return m.updateWatch(msg)
+		}
+	}
+
+	if m.showConflicts {
+		switch msg.(type) {
+		case tea.KeyMsg, ConflictsMsg:
+			return m.updateConflicts(msg)
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		if m.building {
+			// Cancelling kills retoc; wait for the report so the summary
+			// shows what finished before leaving
 			switch msg.String() {
 			case "ctrl+c":
-				if m.cancel != nil {
-					m.cancel()
+				if m.cancelling {
+					return m, tea.Quit
 				}
-				return m, tea.Quit
+				m.quitting = true
+				m.cancelling = true
+				m.cancel()
 
 			case "esc":
-				if m.cancel != nil {
-					m.cancel()
-				}
-				return m, func() tea.Msg { return BackMsg{} }
+				m.cancelling = true
+				m.cancel()
 			}
 			return m, nil
 		}
@@ -86,77 +126,89 @@ func (m PackBuilderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.selected[modIndex] = !m.selected[modIndex]
 			}
 
+		case "v":
+			if m.cursor > 0 {
+				if err := m.cycleEngineVersion(m.cursor - 1); err != nil {
+					m.err = err
+				}
+			}
+
 		case "0":
 			m.cursor = 0
-			m.building = true
-			m.buildStart = time.Now()
-			m.log = ""
-			m.err = nil
-			m.startTime = time.Now()
-			m.ctx, m.cancel = context.WithCancel(context.Background())
-			m.currentTask = "Build ALL"
-			m.parallelMode = false
-			return m, BuildAllAsync(m.ctx, m.mods)
+			return m.startBuild("Build ALL", m.mods, buildAll(false))
+
+		case "w":
+			return m.startWatch()
+
+		case "c":
+			return m.startConflictCheck()
+
+		case "r":
+			if m.cursor > 0 {
+				m.rollback(m.mods[m.cursor-1])
+			}
+
+		case "f":
+			m.cursor = 0
+			return m.startBuild("Force Rebuild ALL", m.mods, buildAll(true))
 
 		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
 			modIndex := int(msg.String()[0] - '1')
 			if modIndex < len(m.mods) {
 				m.cursor = modIndex + 1
-				m.building = true
-				m.buildStart = time.Now()
-				m.log = ""
-				m.err = nil
-				m.startTime = time.Now()
-				m.ctx, m.cancel = context.WithCancel(context.Background())
-				m.parallelMode = false
 				selectedMod := m.mods[modIndex]
-				m.currentTask = selectedMod.DisplayName
-				return m, BuildOneAsync(m.ctx, selectedMod)
+				return m.startBuild(selectedMod.DisplayName, []Mod{selectedMod}, buildOne)
 			}
 
 		case "enter":
-			m.building = true
-			m.buildStart = time.Now()
-			m.log = ""
-			m.err = nil
-			m.startTime = time.Now()
-			m.ctx, m.cancel = context.WithCancel(context.Background())
-
 			if m.cursor == 0 {
-				m.currentTask = "Build ALL"
-				m.parallelMode = false
-				return m, BuildAllAsync(m.ctx, m.mods)
+				return m.startBuild("Build ALL", m.mods, buildAll(false))
 			} else if len(m.selected) > 1 {
-				m.currentTask = fmt.Sprintf("%d Mods Selected", len(m.selected))
-				m.parallelMode = true
 				var selectedMods []Mod
 				for i := range m.mods {
 					if m.selected[i] {
 						selectedMods = append(selectedMods, m.mods[i])
 					}
 				}
-				return m, BuildSelectedParallelAsync(m.ctx, selectedMods)
+				return m.startBuild(fmt.Sprintf("%d Mods Selected", len(m.selected)), selectedMods, BuildSelectedParallelAsync)
 			} else if len(m.selected) == 1 {
-				m.parallelMode = false
 				for i := range m.mods {
 					if m.selected[i] {
-						m.currentTask = m.mods[i].DisplayName
-						return m, BuildOneAsync(m.ctx, m.mods[i])
+						return m.startBuild(m.mods[i].DisplayName, []Mod{m.mods[i]}, buildOne)
 					}
 				}
 			} else {
-				m.parallelMode = false
 				selectedMod := m.mods[m.cursor-1]
-				m.currentTask = selectedMod.DisplayName
-				return m, BuildOneAsync(m.ctx, selectedMod)
+				return m.startBuild(selectedMod.DisplayName, []Mod{selectedMod}, buildOne)
+			}
+		}
+
+	case BuildOutputMsg:
+		m.started[msg.Mod] = true
+		if done, total, ok := parseProgress(msg.Line); ok {
+			m.progress[msg.Mod] = modProgress{done: done, total: total}
+		} else {
+			m.logTail = append(m.logTail, fmt.Sprintf("[%s] %s", msg.Mod, msg.Line))
+			if len(m.logTail) > logTailSize {
+				m.logTail = m.logTail[len(m.logTail)-logTailSize:]
 			}
 		}
+		return m, waitForBuildOutput(m.events)
+
+	case buildTickMsg:
+		if m.building {
+			return m, buildTick()
+		}
+		return m, nil
 
 	case BuildCompleteMsg:
+		if m.quitting {
+			return m, tea.Quit
+		}
 		m.building = false
-		m.log = msg.Log
+		m.cancelling = false
+		m.results = msg.Report.Results
 		m.err = msg.Err
-		m.parallelMode = false
 		if msg.Err == nil {
 			m.selected = make(map[int]bool)
 		}
@@ -166,8 +218,101 @@ func (m PackBuilderModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Build function signature shared by the async builders
+type buildFunc func(ctx context.Context, mods []Mod, events chan BuildOutputMsg) tea.Cmd
+
+func buildAll(force bool) buildFunc {
+	return func(ctx context.Context, mods []Mod, events chan BuildOutputMsg) tea.Cmd {
+		return BuildAllAsync(ctx, mods, events, force)
+	}
+}
+
+func buildOne(ctx context.Context, mods []Mod, events chan BuildOutputMsg) tea.Cmd {
+	return BuildOneAsync(ctx, mods[0], events)
+}
+
+// Reset build state and launch build with live output
+func (m PackBuilderModel) startBuild(task string, mods []Mod, build buildFunc) (tea.Model, tea.Cmd) {
+	m.building = true
+	m.cancelling = false
+	m.notice = ""
+	m.buildStart = time.Now()
+	m.results = nil
+	m.err = nil
+	m.startTime = time.Now()
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	m.currentTask = task
+	m.buildMods = mods
+	m.started = make(map[string]bool)
+	m.progress = make(map[string]modProgress)
+	m.logTail = nil
+	m.events = make(chan BuildOutputMsg, 64)
+
+	return m, tea.Batch(
+		build(m.ctx, mods, m.events),
+		waitForBuildOutput(m.events),
+		buildTick(),
+	)
+}
+
+func buildTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return buildTickMsg(t)
+	})
+}
+
+// Restore the deployed files of a mod's previous build
+func (m *PackBuilderModel) rollback(mod Mod) {
+	m.results = nil
+	backup, err := RollbackMod(mod)
+	if err != nil {
+		m.err = fmt.Errorf("rollback %s: %w", mod.DisplayName, err)
+		m.notice = ""
+		return
+	}
+	m.err = nil
+	m.notice = fmt.Sprintf("✓ Rolled %s back to the build from %s", mod.DisplayName, backup.Created.Format(time.DateTime))
+}
+
+// Step a mod's engine version override through the supported versions
+func (m *PackBuilderModel) cycleEngineVersion(modIndex int) error {
+	mod := &m.mods[modIndex]
+
+	// "" means no override; it follows the last version in the cycle
+	next := EngineVersions[0]
+	for i, v := range EngineVersions {
+		if v == mod.EngineVersion {
+			next = ""
+			if i+1 < len(EngineVersions) {
+				next = EngineVersions[i+1]
+			}
+		}
+	}
+	mod.EngineVersion = next
+
+	if config.Active().ModVersions == nil {
+		config.Active().ModVersions = make(map[string]string)
+	}
+	if next == "" {
+		delete(config.Active().ModVersions, mod.Name)
+	} else {
+		config.Active().ModVersions[mod.Name] = next
+	}
+
+	if err := config.SaveConfig(); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	return nil
+}
+
 // Render UI
 func (m PackBuilderModel) View() string {
+	if m.watching {
+		return m.watchView()
+	}
+	if m.showConflicts {
+		return m.conflictsView()
+	}
 	if m.building {
 		elapsed := time.Since(m.buildStart)
 		if elapsed > 500*time.Millisecond {
@@ -179,7 +324,8 @@ func (m PackBuilderModel) View() string {
 }
 
 func (m PackBuilderModel) menuView() string {
-	s := ui.TitleStyle.Render("TINK.R Toolkit - Pak Builder") + "\n\n"
+	s := ui.TitleStyle.Render("TINK.R Toolkit - Pak Builder") + "\n"
+	s += ui.InfoStyle.Render("Engine: "+engineVersionFor(Mod{})) + "\n\n"
 
 	cursor := " "
 	if m.cursor == 0 {
@@ -201,6 +347,9 @@ func (m PackBuilderModel) menuView() string {
 
 		hotkey := i + 1
 		modName := mod.DisplayName
+		if mod.EngineVersion != "" {
+			modName += " [" + mod.EngineVersion + "]"
+		}
 
 		if m.cursor == i+1 {
 			cursor = ">"
@@ -230,44 +379,84 @@ func (m PackBuilderModel) menuView() string {
 
 	if m.err != nil {
 		s += ui.ErrorStyle.Render("Error: ") + m.err.Error() + "\n"
-	} else if m.log != "" {
-		lines := strings.Split(strings.TrimSpace(m.log), "\n")
-		for _, line := range lines {
-			if strings.HasPrefix(line, "✓") {
-				s += ui.SuccessStyle.Render("✓") + " " + ui.NormalStyle.Render(strings.TrimPrefix(line, "✓ ")) + "\n"
-			} else if strings.HasPrefix(line, "✗") {
-				s += ui.ErrorStyle.Render("✗") + " " + ui.NormalStyle.Render(strings.TrimPrefix(line, "✗ ")) + "\n"
-			}
-		}
-	} else {
+	} else if m.notice != "" {
+		s += ui.SuccessStyle.Render(m.notice) + "\n"
+	}
+	if len(m.results) > 0 {
+		s += renderResults(m.results)
+	} else if m.err == nil {
 		s += "\n"
 	}
 
-	s += "\nSpace to select • Enter to build • Hotkeys: 0-9 • Backspace: Back • ESC: Quit"
+	s += "\nSpace to select • Enter to build • Hotkeys: 0-9 • F: Force rebuild all • W: Watch • C: Conflicts • R: Roll back • V: Engine version • Backspace: Back • ESC: Quit"
 
 	return s
 }
 
+// One line per build result
+func renderResults(results []BuildResult) string {
+	var s string
+	for _, r := range results {
+		switch r.Status {
+		case StatusBuilt:
+			var size int64
+			for _, out := range r.Outputs {
+				size += out.Size
+			}
+			detail := fmt.Sprintf(" (%s, %d file(s), %s)", r.Duration.Round(100*time.Millisecond), len(r.Outputs), utils.FormatSize(size))
+			s += ui.SuccessStyle.Render("✓") + " " + ui.NormalStyle.Render(r.DisplayName) + ui.InfoStyle.Render(detail) + "\n"
+		case StatusUnchanged:
+			s += ui.InfoStyle.Render("= "+r.DisplayName+" (unchanged)") + "\n"
+		case StatusFailed:
+			s += ui.ErrorStyle.Render("✗") + " " + ui.NormalStyle.Render(r.DisplayName) + ui.InfoStyle.Render(": "+r.Error) + "\n"
+		case StatusCancelled:
+			s += ui.ErrorStyle.Render("⊘") + " " + ui.NormalStyle.Render(r.DisplayName) + ui.InfoStyle.Render(" (stopped, Paks unchanged)") + "\n"
+		case StatusPending:
+			s += ui.InfoStyle.Render("· "+r.DisplayName+" (not started)") + "\n"
+		}
+	}
+	return s
+}
+
 func (m PackBuilderModel) buildingView() string {
 	elapsed := time.Since(m.startTime).Round(time.Second)
 
 	s := ui.BuildingStyle.Render(fmt.Sprintf("⚙️  Building: %s", m.currentTask)) + "\n"
-	s += fmt.Sprintf("Elapsed: %s\n\n", elapsed)
+	s += fmt.Sprintf("Elapsed: %s\n", elapsed)
+	if len(m.buildMods) > 1 {
+		s += ui.InfoStyle.Render(fmt.Sprintf("Running up to %d build(s) at once", config.ParallelBuilds())) + "\n"
+	}
+	s += "\n"
 
-	if m.parallelMode {
-		s += ui.InfoStyle.Render("Building mods...") + "\n"
-	} else {
-		if m.log != "" {
-			lines := strings.Split(m.log, "\n")
-			start := 0
-			if len(lines) > 20 {
-				start = len(lines) - 20
-			}
-			s += strings.Join(lines[start:], "\n")
+	nameWidth := 0
+	for _, mod := range m.buildMods {
+		nameWidth = max(nameWidth, len(mod.DisplayName))
+	}
+
+	for _, mod := range m.buildMods {
+		name := fmt.Sprintf("%-*s", nameWidth, mod.DisplayName)
+		if p, ok := m.progress[mod.Name]; ok {
+			percent := float64(p.done) / float64(p.total)
+			s += ui.NormalStyle.Render(name) + "  " + m.progressBar.ViewAs(percent) + ui.InfoStyle.Render(fmt.Sprintf("  %d/%d", p.done, p.total)) + "\n"
+		} else if m.started[mod.Name] {
+			s += ui.NormalStyle.Render(name) + "  " + ui.InfoStyle.Render("waiting for retoc...") + "\n"
+		} else {
+			s += ui.NormalStyle.Render(name) + "  " + ui.InfoStyle.Render("queued") + "\n"
+		}
+	}
+
+	if len(m.logTail) > 0 {
+		s += "\n"
+		for _, line := range m.logTail {
+			s += ui.InfoStyle.Render(line) + "\n"
 		}
 	}
 
-	s += "\n\nPress ESC to cancel"
+	if m.cancelling {
+		s += "\n\n" + ui.ErrorStyle.Render("Cancelling... stopping retoc and cleaning up")
+	} else {
+		s += "\n\nPress ESC to cancel"
+	}
 
 	return s
 }
@@ -0,0 +1,202 @@
+package retoc
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+const (
+	// How often mod folders are polled for changes
+	watchInterval = time.Second
+
+	// Quiet period after the last change before a rebuild starts
+	watchDebounce = 1500 * time.Millisecond
+)
+
+// Watch mode state for the Pak Builder
+type watchState struct {
+	fingerprints map[string]string    // Latest fingerprint per mod name
+	lastChange   map[string]time.Time // Pending mods and when they last changed
+	status       map[string]string    // Last build result per mod name
+}
+
+type watchTickMsg time.Time
+
+type watchScanMsg struct {
+	fingerprints map[string]string
+}
+
+// Start polling mod folders; the first scan becomes the baseline
+func (m PackBuilderModel) startWatch() (tea.Model, tea.Cmd) {
+	m.watching = true
+	m.watch = watchState{
+		lastChange: make(map[string]time.Time),
+		status:     make(map[string]string),
+	}
+	m.logTail = nil
+	m.err = nil
+	return m, scanMods(m.mods)
+}
+
+func (m PackBuilderModel) stopWatch() (tea.Model, tea.Cmd) {
+	if m.building && m.cancel != nil {
+		m.cancel()
+	}
+	m.watching = false
+	return m, nil
+}
+
+func (m PackBuilderModel) updateWatch(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			if m.cancel != nil {
+				m.cancel()
+			}
+			return m, tea.Quit
+
+		case "esc", "w":
+			return m.stopWatch()
+		}
+		return m, nil
+
+	case watchTickMsg:
+		return m, scanMods(m.mods)
+
+	case watchScanMsg:
+		return m.handleWatchScan(msg)
+
+	case BuildCompleteMsg:
+		m.building = false
+		stamp := time.Now().Format("15:04:05")
+		for _, r := range msg.Report.Results {
+			if r.Err != nil {
+				m.watch.status[r.Name] = "✗ failed " + stamp + ": " + r.Error
+			} else {
+				m.watch.status[r.Name] = fmt.Sprintf("✓ built %s (%s)", stamp, r.Duration.Round(100*time.Millisecond))
+			}
+		}
+		m.err = msg.Err
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// Record changed mods and rebuild those that have settled
+func (m PackBuilderModel) handleWatchScan(msg watchScanMsg) (tea.Model, tea.Cmd) {
+	if !m.watching {
+		return m, nil
+	}
+
+	now := time.Now()
+	if m.watch.fingerprints != nil {
+		for name, fp := range msg.fingerprints {
+			if m.watch.fingerprints[name] != fp {
+				m.watch.lastChange[name] = now
+			}
+		}
+	}
+	m.watch.fingerprints = msg.fingerprints
+
+	if m.building {
+		return m, watchTick()
+	}
+
+	var ready []Mod
+	for _, mod := range m.mods {
+		if changed, ok := m.watch.lastChange[mod.Name]; ok && now.Sub(changed) >= watchDebounce {
+			ready = append(ready, mod)
+			delete(m.watch.lastChange, mod.Name)
+		}
+	}
+	if len(ready) == 0 {
+		return m, watchTick()
+	}
+
+	model, cmd := m.startBuild(fmt.Sprintf("%d changed mod(s)", len(ready)), ready, BuildSelectedParallelAsync)
+	return model, tea.Batch(cmd, watchTick())
+}
+
+func watchTick() tea.Cmd {
+	return tea.Tick(watchInterval, func(t time.Time) tea.Msg {
+		return watchTickMsg(t)
+	})
+}
+
+// Fingerprint every mod folder in the background
+func scanMods(mods []Mod) tea.Cmd {
+	return func() tea.Msg {
+		fingerprints := make(map[string]string, len(mods))
+		for _, mod := range mods {
+			// Unreadable folders (e.g. mid-save) keep an empty fingerprint until the next scan
+			fp, _ := modFingerprint(mod)
+			fingerprints[mod.Name] = fp
+		}
+		return watchScanMsg{fingerprints: fingerprints}
+	}
+}
+
+func (m PackBuilderModel) watchView() string {
+	s := ui.TitleStyle.Render("TINK.R Toolkit - Pak Builder") + "\n"
+	s += ui.BuildingStyle.Render("👁  Watch Mode") + ui.InfoStyle.Render(" - mods rebuild automatically when their files change") + "\n\n"
+
+	nameWidth := 0
+	for _, mod := range m.mods {
+		nameWidth = max(nameWidth, len(mod.DisplayName))
+	}
+
+	for _, mod := range m.mods {
+		name := fmt.Sprintf("%-*s", nameWidth, mod.DisplayName)
+		var status string
+
+		if p, ok := m.progress[mod.Name]; ok && m.building && m.isBuilding(mod) {
+			percent := float64(p.done) / float64(p.total)
+			status = m.progressBar.ViewAs(percent)
+		} else if m.building && m.isBuilding(mod) {
+			status = ui.BuildingStyle.Render("building...")
+		} else if _, ok := m.watch.lastChange[mod.Name]; ok {
+			status = ui.InfoStyle.Render("changed, waiting for saves to settle...")
+		} else if last, ok := m.watch.status[mod.Name]; ok {
+			if strings.HasPrefix(last, "✗") {
+				status = ui.ErrorStyle.Render(last)
+			} else {
+				status = ui.SuccessStyle.Render(last)
+			}
+		} else {
+			status = ui.InfoStyle.Render("watching")
+		}
+
+		s += ui.NormalStyle.Render(name) + "  " + status + "\n"
+	}
+
+	if len(m.logTail) > 0 {
+		s += "\n"
+		for _, line := range m.logTail {
+			s += ui.InfoStyle.Render(line) + "\n"
+		}
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render("Error: ") + m.err.Error() + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("W/ESC: Stop watching • Ctrl+C: Quit")
+	return s
+}
+
+// Report whether mod is part of the running build
+func (m PackBuilderModel) isBuilding(mod Mod) bool {
+	for _, b := range m.buildMods {
+		if b.Name == mod.Name {
+			return true
+		}
+	}
+	return false
+}
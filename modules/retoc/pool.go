@@ -0,0 +1,123 @@
+package retoc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Build mods on a bounded worker pool of config.ParallelBuilds() workers.
+// With skipUnchanged, mods whose sources match the build cache are skipped.
+// Results are returned in the same order as mods.
+func BuildMods(ctx context.Context, mods []Mod, events chan<- BuildOutputMsg, skipUnchanged bool) BuildReport {
+	report := BuildReport{
+		Started: time.Now(),
+		Profile: config.Active().Name,
+		Results: make([]BuildResult, len(mods)),
+	}
+	cache := loadBuildCache()
+
+	// Only recorded for the report; a retoc that can't say its version can still build
+	runner := currentRunner()
+	report.RetocVersion, _ = runner.Version(ctx)
+	report.RetocInstall = config.Active().RetocVersion
+	if execRunner, ok := runner.(ExecRunner); ok {
+		report.RetocExecutable = execRunner.Executable
+	}
+
+	// A corrupt manifest is left alone rather than overwritten with a partial one
+	manifest, manifestErr := loadManifest()
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := min(config.ParallelBuilds(), len(mods))
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				report.Results[i] = buildCached(ctx, cache, manifest, mods[i], i, len(mods), events, skipUnchanged)
+			}
+		}()
+	}
+
+	// Feed the queue until everything is handed out or the build is cancelled
+queueLoop:
+	for i := range mods {
+		select {
+		case queue <- i:
+		case <-ctx.Done():
+			for j := i; j < len(mods); j++ {
+				report.Results[j] = pendingResult(mods[j])
+			}
+			break queueLoop
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	// The cache is only an optimisation; failing to save it just means a rebuild next time
+	cache.save()
+	if manifestErr == nil {
+		manifest.save()
+	}
+
+	report.Duration = time.Since(report.Started)
+	return report
+}
+
+// Build one mod unless the cache says it's up to date
+func buildCached(ctx context.Context, cache *buildCache, manifest *deployManifest, mod Mod, index, count int, events chan<- BuildOutputMsg, skipUnchanged bool) BuildResult {
+	// Handed out just as the run was cancelled
+	if ctx.Err() != nil {
+		return pendingResult(mod)
+	}
+
+	header := fmt.Sprintf("==== [%d/%d] Building %s ====\n", index+1, count, mod.DisplayName)
+
+	fingerprint, fpErr := modFingerprint(mod)
+	if fpErr == nil && skipUnchanged && cache.upToDate(mod, fingerprint) {
+		sendBuildOutput(ctx, events, mod, "Unchanged, skipped")
+		result := newBuildResult(mod)
+		result.finish(header+"  Unchanged since last build, skipping\n", nil)
+		result.Status = StatusUnchanged
+		return result
+	}
+
+	sendBuildOutput(ctx, events, mod, "Build started")
+	result := BuildMod(ctx, mod, events)
+	result.Log = header + result.Log
+
+	if result.Err == nil && fpErr == nil {
+		cache.record(mod, fingerprint)
+	}
+	if result.Err == nil && manifest != nil {
+		if err := manifest.record(mod, result.Outputs); err != nil {
+			result.Log += fmt.Sprintf("  Warning: couldn't record deployment: %v\n", err)
+		}
+	}
+	return result
+}
+
+// Result for a mod the cancelled run never got to
+func pendingResult(mod Mod) BuildResult {
+	result := newBuildResult(mod)
+	result.finish("", fmt.Errorf("not started: %w", errBuildCancelled))
+	result.Status = StatusPending
+	return result
+}
+
+// Send a line of build output unless the build was cancelled
+func sendBuildOutput(ctx context.Context, events chan<- BuildOutputMsg, mod Mod, line string) {
+	if events == nil {
+		return
+	}
+	select {
+	case events <- BuildOutputMsg{Mod: mod.Name, Line: line}:
+	case <-ctx.Done():
+	}
+}
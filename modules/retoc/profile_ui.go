@@ -0,0 +1,249 @@
+package retoc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
+)
+
+// Text prompt shown on the profile screen
+type profileInput int
+
+const (
+	inputNone profileInput = iota
+	inputName
+	inputAESKey
+	inputMods
+)
+
+type ProfileSelectModel struct {
+	cursor    int
+	input     profileInput
+	textInput textinput.Model
+	err       error
+}
+
+func NewProfileSelectModel() ProfileSelectModel {
+	ti := textinput.New()
+	ti.Width = 70
+
+	cursor := 0
+	active := config.Active()
+	for i, p := range config.Current.Profiles {
+		if p == active {
+			cursor = i
+		}
+	}
+
+	return ProfileSelectModel{
+		cursor:    cursor,
+		textInput: ti,
+	}
+}
+
+func (m ProfileSelectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ProfileSelectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.input != inputNone {
+		return m.updateInput(msg)
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+
+		case "backspace":
+			return m, func() tea.Msg { return ui.BackMsg{} }
+
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down":
+			if m.cursor < len(config.Current.Profiles)-1 {
+				m.cursor++
+			}
+
+		case "enter":
+			if err := config.UseProfile(config.Current.Profiles[m.cursor].Name); err != nil {
+				m.err = err
+				return m, nil
+			}
+			return m, tea.Quit
+
+		case "n":
+			m.input = inputName
+			m.err = nil
+			m.textInput.Placeholder = "Profile name (e.g. Grounded 2)..."
+			m.textInput.EchoMode = textinput.EchoNormal
+			m.textInput.SetValue("")
+			m.textInput.Focus()
+			return m, textinput.Blink
+
+		case "k":
+			m.input = inputAESKey
+			m.err = nil
+			m.textInput.Placeholder = "64 hex characters or base64 (empty to clear)..."
+			m.textInput.EchoMode = textinput.EchoPassword
+			m.textInput.SetValue("")
+			m.textInput.Focus()
+			return m, textinput.Blink
+
+		case "m":
+			m.input = inputMods
+			m.err = nil
+			m.textInput.Placeholder = "Mod folders, comma-separated (empty for all)..."
+			m.textInput.EchoMode = textinput.EchoNormal
+			m.textInput.SetValue(strings.Join(config.Current.Profiles[m.cursor].Mods, ", "))
+			m.textInput.Focus()
+			return m, textinput.Blink
+
+		case "d":
+			if err := config.RemoveProfile(config.Current.Profiles[m.cursor].Name); err != nil {
+				m.err = err
+				return m, nil
+			}
+			if m.cursor >= len(config.Current.Profiles) {
+				m.cursor = len(config.Current.Profiles) - 1
+			}
+			m.err = nil
+		}
+	}
+
+	return m, nil
+}
+
+func (m ProfileSelectModel) updateInput(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			return m, tea.Quit
+
+		case tea.KeyEsc:
+			m.input = inputNone
+			m.textInput.Blur()
+			return m, nil
+
+		case tea.KeyEnter:
+			var err error
+			switch m.input {
+			case inputName:
+				if _, err = config.AddProfile(m.textInput.Value()); err == nil {
+					m.cursor = len(config.Current.Profiles) - 1
+				}
+			case inputAESKey:
+				err = m.setAESKey(m.textInput.Value())
+			case inputMods:
+				config.Current.Profiles[m.cursor].Mods = config.ParseModList(m.textInput.Value())
+				err = config.SaveConfig()
+			}
+			if err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.input = inputNone
+			m.textInput.Blur()
+			m.err = nil
+			return m, nil
+		}
+	}
+
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// Validate and store the AES key of the highlighted profile
+func (m ProfileSelectModel) setAESKey(value string) error {
+	profile := config.Current.Profiles[m.cursor]
+
+	key := ""
+	if value != "" {
+		var err error
+		if key, err = config.NormalizeAESKey(value); err != nil {
+			return err
+		}
+	}
+
+	profile.AESKey = key
+	return config.SaveConfig()
+}
+
+func (m ProfileSelectModel) View() string {
+	s := ui.TitleStyle.Render("Game Profiles") + "\n\n"
+
+	active := config.Active()
+	for i, p := range config.Current.Profiles {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		line := fmt.Sprintf("%s %s", cursor, p.Name)
+		if p == active {
+			line += " (active)"
+		}
+
+		if m.cursor == i {
+			s += ui.SelectedStyle.Render(line) + "\n"
+			s += ui.InfoStyle.Render("     Mods: "+valueOrUnset(p.ModsDir)) + "\n"
+			s += ui.InfoStyle.Render("     Paks: "+valueOrUnset(p.PakDir)) + "\n"
+			s += ui.InfoStyle.Render("     Builds: "+modsLabel(p.Mods)) + "\n"
+			s += ui.InfoStyle.Render("     Engine: "+valueOrUnset(p.EngineVersion)) + "\n"
+			s += ui.InfoStyle.Render("     AES key: "+valueOrUnset(config.MaskAESKey(p.AESKey))) + "\n"
+			s += ui.InfoStyle.Render("     Retoc: "+retocLabel(p.RetocVersion)) + "\n"
+		} else {
+			s += ui.NormalStyle.Render(line) + "\n"
+		}
+	}
+
+	switch m.input {
+	case inputName:
+		s += "\n" + ui.NormalStyle.Render("New profile name:") + "\n"
+		s += m.textInput.View() + "\n"
+	case inputAESKey:
+		s += "\n" + ui.NormalStyle.Render("AES key for "+config.Current.Profiles[m.cursor].Name+":") + "\n"
+		s += m.textInput.View() + "\n"
+	case inputMods:
+		s += "\n" + ui.NormalStyle.Render("Mods to build for "+config.Current.Profiles[m.cursor].Name+":") + "\n"
+		s += m.textInput.View() + "\n"
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	}
+
+	if m.input != inputNone {
+		s += "\n" + ui.InfoStyle.Render("Enter: Save • ESC: Cancel")
+	} else {
+		s += "\n" + ui.InfoStyle.Render("↑/↓: Navigate • Enter: Use profile • N: New • K: AES key • M: Mods • D: Delete • ESC: Back")
+	}
+
+	return s
+}
+
+// Mods a profile builds, all of ModsDir when none are listed
+func modsLabel(mods []string) string {
+	if len(mods) == 0 {
+		return "all mods"
+	}
+	return strings.Join(mods, ", ")
+}
+
+func valueOrUnset(value string) string {
+	if value == "" {
+		return "(not set)"
+	}
+	return value
+}
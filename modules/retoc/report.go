@@ -0,0 +1,157 @@
+package retoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Outcome of a mod build
+type BuildStatus string
+
+const (
+	StatusBuilt     BuildStatus = "built"
+	StatusUnchanged BuildStatus = "unchanged"
+	StatusFailed    BuildStatus = "failed"
+	StatusCancelled BuildStatus = "cancelled" // Stopped while building; nothing was deployed
+	StatusPending   BuildStatus = "pending"   // Never started because the run was cancelled
+)
+
+var errBuildCancelled = errors.New("build cancelled")
+
+// Container file deployed by a build
+type OutputFile struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// Structured result of building one mod
+type BuildResult struct {
+	Name          string        `json:"name"`
+	DisplayName   string        `json:"display_name"`
+	Status        BuildStatus   `json:"status"`
+	EngineVersion string        `json:"engine_version"`
+	Started       time.Time     `json:"started"`
+	Duration      time.Duration `json:"duration_ns"`
+	Outputs       []OutputFile  `json:"outputs,omitempty"`
+	Stdout        string        `json:"stdout,omitempty"`
+	Stderr        string        `json:"stderr,omitempty"`
+	ExitCode      int           `json:"exit_code"`
+	Error         string        `json:"error,omitempty"`
+	Log           string        `json:"log"`
+	Err           error         `json:"-"`
+}
+
+// Results of one build run
+type BuildReport struct {
+	Started         time.Time     `json:"started"`
+	Duration        time.Duration `json:"duration_ns"`
+	Profile         string        `json:"profile"`
+	RetocVersion    string        `json:"retoc_version,omitempty"`    // As reported by retoc --version, if it supports it
+	RetocInstall    string        `json:"retoc_install,omitempty"`    // Managed install the profile is pinned to
+	RetocExecutable string        `json:"retoc_executable,omitempty"` // Binary the mods were built with
+	Results         []BuildResult `json:"results"`
+}
+
+func newBuildResult(mod Mod) BuildResult {
+	return BuildResult{
+		Name:          mod.Name,
+		DisplayName:   mod.DisplayName,
+		EngineVersion: engineVersionFor(mod),
+		Started:       time.Now(),
+	}
+}
+
+// Fill in the final fields of a result once its build has ended
+func (r *BuildResult) finish(log string, err error) {
+	r.Duration = time.Since(r.Started)
+	r.Log = log
+	r.Err = err
+	r.Status = StatusBuilt
+	if err != nil {
+		r.Status = StatusFailed
+		if errors.Is(err, errBuildCancelled) {
+			r.Status = StatusCancelled
+		}
+		r.Error = err.Error()
+
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			r.ExitCode = exitErr.ExitCode()
+		} else if r.ExitCode == 0 {
+			r.ExitCode = -1
+		}
+	}
+}
+
+// Which retoc built the run: the pinned install and/or reported version
+func (r BuildReport) RetocLabel() string {
+	switch {
+	case r.RetocInstall != "" && r.RetocVersion != "" && r.RetocInstall != r.RetocVersion:
+		return fmt.Sprintf("%s (%s)", r.RetocInstall, r.RetocVersion)
+	case r.RetocInstall != "":
+		return r.RetocInstall
+	}
+	return r.RetocVersion
+}
+
+// Count results with the given status
+func (r BuildReport) Count(status BuildStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Whether the run was cancelled before every mod finished
+func (r BuildReport) Cancelled() bool {
+	return r.Count(StatusCancelled)+r.Count(StatusPending) > 0
+}
+
+// Display names of results with the given status
+func (r BuildReport) Names(status BuildStatus) []string {
+	var names []string
+	for _, result := range r.Results {
+		if result.Status == status {
+			names = append(names, result.DisplayName)
+		}
+	}
+	return names
+}
+
+// Full log of every result in run order
+func (r BuildReport) Log() string {
+	var log strings.Builder
+	for _, result := range r.Results {
+		log.WriteString(result.Log)
+		log.WriteString("\n")
+	}
+	return log.String()
+}
+
+// Write report as JSON next to the config
+func SaveBuildReport(report BuildReport) error {
+	dataDir, err := config.DataDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dataDir, "last_build.json"), data, 0644)
+}
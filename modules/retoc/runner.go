@@ -0,0 +1,109 @@
+package retoc
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Runs retoc subcommands. Each returns retoc's captured stdout and stderr;
+// the streaming ones also pass every output line to onLine, which may be nil.
+type Runner interface {
+	// Convert a legacy mod folder into a Zen container at output (.utoc)
+	ToZen(ctx context.Context, input, output, version string, onLine func(string)) (stdout, stderr string, err error)
+	// Convert Zen containers under input to legacy assets, optionally only paths containing a filter
+	ToLegacy(ctx context.Context, input, output string, filters []string, onLine func(string)) (stdout, stderr string, err error)
+	// Print the directory index of a container
+	List(ctx context.Context, utoc string) (stdout, stderr string, err error)
+	// Print a container's header information
+	Info(ctx context.Context, utoc string) (stdout, stderr string, err error)
+	// Print a container's package manifest
+	Manifest(ctx context.Context, utoc string) (stdout, stderr string, err error)
+	// Extract a container's raw chunks to output
+	Unpack(ctx context.Context, utoc, output string, onLine func(string)) (stdout, stderr string, err error)
+	// Version number retoc reports, e.g. 0.1.2
+	Version(ctx context.Context) (string, error)
+}
+
+// Runner used instead of the retoc executable, for tests
+var runnerOverride Runner
+
+// Replace the runner used for every retoc call; nil restores the executable
+func SetRunner(r Runner) {
+	runnerOverride = r
+}
+
+// The runner for the current config
+func currentRunner() Runner {
+	if runnerOverride != nil {
+		return runnerOverride
+	}
+	return NewExecRunner()
+}
+
+// Runs the retoc executable
+type ExecRunner struct {
+	Executable string
+	Dir        string
+	AESKey     string // Passed as --aes-key and masked in output
+}
+
+// Runner for the resolved retoc binary and the active profile's AES key.
+// retoc runs from its own folder so it finds the Oodle library.
+func NewExecRunner() ExecRunner {
+	executable := retocExecutable()
+	return ExecRunner{
+		Executable: executable,
+		Dir:        filepath.Dir(executable),
+		AESKey:     config.Active().AESKey,
+	}
+}
+
+func (r ExecRunner) run(ctx context.Context, onLine func(string), args ...string) (string, string, error) {
+	if r.AESKey != "" {
+		args = append([]string{"--aes-key", r.AESKey}, args...)
+	}
+
+	cmd := exec.CommandContext(ctx, r.Executable, args...)
+	cmd.Dir = r.Dir
+	return runStreaming(cmd, r.AESKey, onLine)
+}
+
+func (r ExecRunner) ToZen(ctx context.Context, input, output, version string, onLine func(string)) (string, string, error) {
+	return r.run(ctx, onLine, "to-zen", "--version", version, "--", input, output)
+}
+
+func (r ExecRunner) ToLegacy(ctx context.Context, input, output string, filters []string, onLine func(string)) (string, string, error) {
+	args := []string{"to-legacy"}
+	for _, filter := range filters {
+		args = append(args, "--filter", filter)
+	}
+	args = append(args, "--", input, output)
+	return r.run(ctx, onLine, args...)
+}
+
+func (r ExecRunner) List(ctx context.Context, utoc string) (string, string, error) {
+	return r.run(ctx, nil, "list", "--", utoc)
+}
+
+func (r ExecRunner) Info(ctx context.Context, utoc string) (string, string, error) {
+	return r.run(ctx, nil, "info", "--", utoc)
+}
+
+func (r ExecRunner) Manifest(ctx context.Context, utoc string) (string, string, error) {
+	return r.run(ctx, nil, "manifest", "--", utoc)
+}
+
+func (r ExecRunner) Unpack(ctx context.Context, utoc, output string, onLine func(string)) (string, string, error) {
+	return r.run(ctx, onLine, "unpack", "--", utoc, output)
+}
+
+func (r ExecRunner) Version(ctx context.Context) (string, error) {
+	output, err := probeRetoc(ctx, r.Executable, "--version")
+	if err != nil {
+		return "", err
+	}
+	return parseRetocVersion(output), nil
+}
@@ -16,15 +16,17 @@ type setupStep int
 const (
 	stepModsDir setupStep = iota
 	stepPakDir
+	stepEngineVersion
 	stepComplete
 )
 
 type PackSetupModel struct {
-	step      setupStep
-	textInput textinput.Model
-	modsDir   string
-	pakDir    string
-	err       error
+	step          setupStep
+	textInput     textinput.Model
+	modsDir       string
+	pakDir        string
+	versionCursor int
+	err           error
 }
 
 type transitionToPackBuilderMsg struct{}
@@ -36,15 +38,31 @@ func NewPackSetupModel() PackSetupModel {
 	ti.Width = 60
 
 	step := stepModsDir
-	if config.Current.ModsDir != "" {
+	if config.Active().ModsDir != "" {
 		step = stepPakDir
+		if config.Active().PakDir != "" && config.Active().EngineVersion == "" {
+			step = stepEngineVersion
+		}
+	}
+
+	// Start the picker on the configured version
+	current := config.Active().EngineVersion
+	if current == "" {
+		current = config.DefaultEngineVersion
+	}
+	versionCursor := 0
+	for i, v := range EngineVersions {
+		if v == current {
+			versionCursor = i
+		}
 	}
 
 	return PackSetupModel{
-		step:      step,
-		textInput: ti,
-		modsDir:   config.Current.ModsDir,
-		pakDir:    config.Current.PakDir,
+		step:          step,
+		textInput:     ti,
+		modsDir:       config.Active().ModsDir,
+		pakDir:        config.Active().PakDir,
+		versionCursor: versionCursor,
 	}
 }
 
@@ -64,7 +82,24 @@ func (m PackSetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case tea.KeyEsc:
 			return m, tea.Quit
 
+		case tea.KeyUp:
+			if m.step == stepEngineVersion && m.versionCursor > 0 {
+				m.versionCursor--
+				return m, nil
+			}
+
+		case tea.KeyDown:
+			if m.step == stepEngineVersion && m.versionCursor < len(EngineVersions)-1 {
+				m.versionCursor++
+				return m, nil
+			}
+
 		case tea.KeyBackspace:
+			if m.step == stepEngineVersion {
+				m.step = stepPakDir
+				m.err = nil
+				return m, nil
+			}
 			if m.textInput.Value() == "" {
 				return m, func() tea.Msg { return ui.BackMsg{} }
 			}
@@ -105,7 +140,7 @@ func (m PackSetupModel) handleEnter() (tea.Model, tea.Cmd) {
 		}
 
 		m.modsDir = normalized
-		config.Current.ModsDir = normalized
+		config.Active().ModsDir = normalized
 
 		if err := config.SaveConfig(); err != nil {
 			m.err = fmt.Errorf("failed to save config: %w", err)
@@ -125,7 +160,20 @@ func (m PackSetupModel) handleEnter() (tea.Model, tea.Cmd) {
 		}
 
 		m.pakDir = normalized
-		config.Current.PakDir = normalized
+		config.Active().PakDir = normalized
+
+		if err := config.SaveConfig(); err != nil {
+			m.err = fmt.Errorf("failed to save config: %w", err)
+			return m, nil
+		}
+
+		m.step = stepEngineVersion
+		m.textInput.SetValue("")
+		m.err = nil
+		return m, nil
+
+	case stepEngineVersion:
+		config.Active().EngineVersion = EngineVersions[m.versionCursor]
 
 		if err := config.SaveConfig(); err != nil {
 			m.err = fmt.Errorf("failed to save config: %w", err)
@@ -162,6 +210,20 @@ func (m PackSetupModel) View() string {
 		s += ui.InfoStyle.Render("  Example: E:\\SteamLibrary\\steamapps\\common\\Grounded2\\Augusta\\Content\\Paks") + "\n\n"
 		s += m.textInput.View() + "\n\n"
 
+	case stepEngineVersion:
+		s += ui.SuccessStyle.Render("✓ Mods directory: "+m.modsDir) + "\n"
+		s += ui.SuccessStyle.Render("✓ Paks directory: "+m.pakDir) + "\n\n"
+		s += ui.NormalStyle.Render("Unreal Engine Version:") + "\n"
+		s += ui.InfoStyle.Render("  The engine version the game was built with") + "\n\n"
+		for i, version := range EngineVersions {
+			if i == m.versionCursor {
+				s += ui.SelectedStyle.Render("> "+version) + "\n"
+			} else {
+				s += ui.NormalStyle.Render("  "+version) + "\n"
+			}
+		}
+		s += "\n"
+
 	case stepComplete:
 		s += ui.SuccessStyle.Render("✓ Configuration saved!") + "\n\n"
 		s += ui.InfoStyle.Render("Loading Pack Builder...") + "\n"
@@ -0,0 +1,118 @@
+package retoc
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Matches retoc's progress bar, e.g. "[00:00:06] ####   22522/22522"
+var progressPattern = regexp.MustCompile(`^\[\d+:\d+:\d+\].*?(\d+)/(\d+)\s*$`)
+
+// Returns the path of the retoc executable, falling back to the configured
+// retoc folder when it can't be found so errors name the expected location
+func retocExecutable() string {
+	if path, _, err := ResolveRetoc(); err == nil {
+		return path
+	}
+	return filepath.Join(config.Current.RetocDir, retocBinaryName())
+}
+
+// Mask an AES key anywhere it appears in retoc output
+func redactAESKey(line, key string) string {
+	if key == "" {
+		return line
+	}
+
+	masked := config.MaskAESKey(key)
+	bare := strings.TrimPrefix(key, "0x")
+	for _, variant := range []string{key, bare, strings.ToLower(bare)} {
+		line = strings.ReplaceAll(line, variant, masked)
+	}
+	return line
+}
+
+// Runs cmd and passes each line of its stdout and stderr to onLine, with
+// aesKey masked. Returns the captured stdout and stderr once the process exits.
+func runStreaming(cmd *exec.Cmd, aesKey string, onLine func(string)) (stdout, stderr string, err error) {
+	outPipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", "", err
+	}
+	errPipe, err := cmd.StderrPipe()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return "", "", err
+	}
+
+	// onLine may be called from both readers
+	var mu sync.Mutex
+	var outBuf, errBuf strings.Builder
+	var wg sync.WaitGroup
+	read := func(r io.Reader, buf *strings.Builder) {
+		defer wg.Done()
+		scanner := bufio.NewScanner(r)
+		scanner.Split(scanLinesOrCR)
+		for scanner.Scan() {
+			line := redactAESKey(strings.TrimSpace(scanner.Text()), aesKey)
+			if line == "" {
+				continue
+			}
+			buf.WriteString(line + "\n")
+			if onLine != nil {
+				mu.Lock()
+				onLine(line)
+				mu.Unlock()
+			}
+		}
+		// Drain anything left so the child never blocks on a full pipe
+		io.Copy(io.Discard, r)
+	}
+
+	wg.Add(2)
+	go read(outPipe, &outBuf)
+	go read(errPipe, &errBuf)
+
+	// Pipes must be fully read before Wait closes them
+	wg.Wait()
+	err = cmd.Wait()
+
+	return outBuf.String(), errBuf.String(), err
+}
+
+// Parse a retoc progress line into done/total counts
+func parseProgress(line string) (done, total int, ok bool) {
+	m := progressPattern.FindStringSubmatch(line)
+	if m == nil {
+		return 0, 0, false
+	}
+	done, _ = strconv.Atoi(m[1])
+	total, _ = strconv.Atoi(m[2])
+	return done, total, total > 0
+}
+
+// Split function that treats both \n and \r as line terminators.
+// retoc redraws its progress bar with carriage returns.
+func scanLinesOrCR(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
@@ -0,0 +1,117 @@
+package retoc
+
+import (
+	"sort"
+	"strings"
+)
+
+// Directory or file in a container's directory index
+type treeNode struct {
+	name     string
+	path     string
+	size     int64
+	files    int
+	isDir    bool
+	expanded bool
+	children []*treeNode
+	index    map[string]*treeNode
+}
+
+// Visible tree line
+type treeRow struct {
+	node  *treeNode
+	depth int
+}
+
+// Build a directory tree from container entries, summing sizes into directories
+func buildTree(entries []ContainerEntry) *treeNode {
+	root := &treeNode{path: "/", isDir: true, expanded: true, index: make(map[string]*treeNode)}
+
+	for _, entry := range entries {
+		parts := strings.Split(strings.Trim(entry.Path, "/"), "/")
+		node := root
+		node.size += entry.Size
+		node.files++
+
+		for i, part := range parts {
+			child, ok := node.index[part]
+			if !ok {
+				child = &treeNode{
+					name:  part,
+					path:  node.childPath(part),
+					isDir: i < len(parts)-1,
+					index: make(map[string]*treeNode),
+				}
+				node.index[part] = child
+				node.children = append(node.children, child)
+			}
+			child.size += entry.Size
+			child.files++
+			node = child
+		}
+	}
+
+	root.sort()
+	return root
+}
+
+func (n *treeNode) childPath(name string) string {
+	if n.path == "/" {
+		return "/" + name
+	}
+	return n.path + "/" + name
+}
+
+// Directories first, then by name
+func (n *treeNode) sort() {
+	sort.Slice(n.children, func(i, j int) bool {
+		a, b := n.children[i], n.children[j]
+		if a.isDir != b.isDir {
+			return a.isDir
+		}
+		return strings.ToLower(a.name) < strings.ToLower(b.name)
+	})
+	for _, child := range n.children {
+		child.sort()
+	}
+}
+
+// Flatten the expanded part of the tree. With a filter, only files whose
+// path contains it (and their directories) are shown, fully expanded.
+func visibleRows(root *treeNode, filter string) []treeRow {
+	filter = strings.ToLower(filter)
+
+	var rows []treeRow
+	var walk func(n *treeNode, depth int) bool
+	walk = func(n *treeNode, depth int) bool {
+		if !n.isDir {
+			if filter != "" && !strings.Contains(strings.ToLower(n.path), filter) {
+				return false
+			}
+			rows = append(rows, treeRow{node: n, depth: depth})
+			return true
+		}
+
+		at := len(rows)
+		rows = append(rows, treeRow{node: n, depth: depth})
+		if !n.expanded && filter == "" {
+			return true
+		}
+
+		matched := false
+		for _, child := range n.children {
+			if walk(child, depth+1) {
+				matched = true
+			}
+		}
+		if filter != "" && !matched {
+			rows = rows[:at]
+		}
+		return matched || filter == ""
+	}
+
+	for _, child := range root.children {
+		walk(child, 0)
+	}
+	return rows
+}
@@ -0,0 +1,97 @@
+package retoc
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Index of a small container, as parseListOutput returns it
+var treeEntries = parseListOutput(`
+a1b2c3d4e5f6a7b8c9d0e1f2  ExportBundleData  1000  ../../../Game/Content/Weapons/Axe.uasset
+a1b2c3d4e5f6a7b8c9d0e1f3  BulkData          200   ../../../Game/Content/Weapons/Axe.ubulk
+a1b2c3d4e5f6a7b8c9d0e1f4  ExportBundleData  300   ../../../Game/Content/My Mod/Big Axe.uasset
+a1b2c3d4e5f6a7b8c9d0e1f5  ExportBundleData  40    ../../../Game/Content/Maps/Arena.umap
+a1b2c3d4e5f6a7b8c9d0e1f6  ShaderCode              ../../../Game/Config/DefaultGame.ini
+`)
+
+func TestVisibleRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		expand []string // Directories to expand, by tree path
+		filter string
+		want   []string // Rows, indented two spaces per level
+	}{
+		{"collapsed", nil, "", []string{"Game"}},
+		{"nested", []string{"/Game", "/Game/Content"}, "", []string{
+			"Game", "  Config", "  Content", "    Maps", "    My Mod", "    Weapons",
+		}},
+		{"files after folders", []string{"/Game", "/Game/Content", "/Game/Content/Weapons"}, "", []string{
+			"Game", "  Config", "  Content", "    Maps", "    My Mod", "    Weapons", "      Axe.uasset", "      Axe.ubulk",
+		}},
+		{"filter expands matches", nil, "AXE", []string{
+			"Game", "  Content", "    My Mod", "      Big Axe.uasset", "    Weapons", "      Axe.uasset", "      Axe.ubulk",
+		}},
+		{"filter with spaces", nil, "big axe", []string{"Game", "  Content", "    My Mod", "      Big Axe.uasset"}},
+		{"filter matches folders", nil, "/maps/", []string{"Game", "  Content", "    Maps", "      Arena.umap"}},
+		{"filter without matches", nil, "sword", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := buildTree(treeEntries)
+			for _, path := range tt.expand {
+				node := findNode(root, path)
+				if node == nil {
+					t.Fatalf("no node at %s", path)
+				}
+				node.expanded = true
+			}
+
+			var got []string
+			for _, row := range visibleRows(root, tt.filter) {
+				got = append(got, strings.Repeat("  ", row.depth)+row.node.name)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rows =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestBuildTreeSumsSizes(t *testing.T) {
+	root := buildTree(treeEntries)
+
+	tests := []struct {
+		path  string
+		size  int64
+		files int
+	}{
+		{"/", 1540, 5},
+		{"/Game/Content", 1540, 4},
+		{"/Game/Content/Weapons", 1200, 2},
+		{"/Game/Config/DefaultGame.ini", 0, 1},
+	}
+	for _, tt := range tests {
+		node := findNode(root, tt.path)
+		if node == nil {
+			t.Errorf("no node at %s", tt.path)
+			continue
+		}
+		if node.size != tt.size || node.files != tt.files {
+			t.Errorf("%s: size %d, files %d; want %d, %d", tt.path, node.size, node.files, tt.size, tt.files)
+		}
+	}
+}
+
+func findNode(n *treeNode, path string) *treeNode {
+	if n.path == path {
+		return n
+	}
+	for _, child := range n.children {
+		if found := findNode(child, path); found != nil {
+			return found
+		}
+	}
+	return nil
+}
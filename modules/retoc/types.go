@@ -1,14 +1,27 @@
 package retoc
 
 type Mod struct {
-	Name        string
-	DisplayName string
-	Path        string
+	Name          string
+	DisplayName   string
+	Path          string
+	EngineVersion string
 }
 
 type BuildCompleteMsg struct {
-	Log        string
-	Err        error
-	BuiltMods  []string
-	FailedMods []string
+	Report BuildReport
+	Err    error
+}
+
+type BuildOutputMsg struct {
+	Mod  string
+	Line string
+}
+
+type UnpackLineMsg struct {
+	Line string
+}
+
+type UnpackCompleteMsg struct {
+	Result UnpackResult
+	Err    error
 }
@@ -1 +1,97 @@
 package retoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Matches retoc's summary, e.g. "Extracted 22522 (0 failed) legacy assets"
+var extractedPattern = regexp.MustCompile(`Extracted (\d+) \((\d+) failed\) legacy assets`)
+
+// Outcome of a Zen → Legacy conversion
+type UnpackResult struct {
+	PaksDir   string
+	OutputDir string
+	Filters   []string
+	Extracted int
+	Failed    int
+	Output    string
+}
+
+// Execute retoc unpacking process. With filters, only assets whose path
+// contains one of them are converted.
+func UnpackGame(ctx context.Context, lines chan<- string, paksDir, outputDir string, filters []string) (UnpackResult, error) {
+	result := UnpackResult{
+		PaksDir:   paksDir,
+		OutputDir: outputDir,
+		Filters:   filters,
+	}
+
+	stdout, stderr, err := currentRunner().ToLegacy(ctx, paksDir, outputDir, filters, func(line string) {
+		if lines == nil {
+			return
+		}
+		select {
+		case lines <- line:
+		case <-ctx.Done():
+		}
+	})
+	output := stdout + stderr
+	result.Output = output
+	if err != nil {
+		if ctx.Err() == context.Canceled {
+			return result, errors.New("unpack cancelled")
+		}
+		return result, fmt.Errorf("retoc failed: %w", err)
+	}
+
+	if m := extractedPattern.FindStringSubmatch(output); m != nil {
+		result.Extracted, _ = strconv.Atoi(m[1])
+		result.Failed, _ = strconv.Atoi(m[2])
+	} else {
+		// Fall back to counting what landed on disk
+		result.Extracted = countAssets(outputDir)
+	}
+
+	return result, nil
+}
+
+// Unpack in the background, forwarding retoc output to lines
+func UnpackAsync(ctx context.Context, lines chan string, paksDir, outputDir string, filters []string) tea.Cmd {
+	return func() tea.Msg {
+		defer close(lines)
+		result, err := UnpackGame(ctx, lines, paksDir, outputDir, filters)
+		return UnpackCompleteMsg{Result: result, Err: err}
+	}
+}
+
+// Wait for the next line of retoc output
+func waitForUnpackLine(lines <-chan string) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-lines
+		if !ok {
+			return nil
+		}
+		return UnpackLineMsg{Line: line}
+	}
+}
+
+// Count legacy assets under dir
+func countAssets(dir string) int {
+	count := 0
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".uasset") {
+			count++
+		}
+		return nil
+	})
+	return count
+}
@@ -1,44 +1,323 @@
 package retoc
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
 	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/ui"
 )
 
+type unpackStep int
+
+const (
+	unpackStepPaksDir unpackStep = iota
+	unpackStepOutputDir
+	unpackStepRunning
+	unpackStepComplete
+)
+
 type UnpackSetupModel struct {
-	// TODO: Add fields for unpack setup
+	step      unpackStep
+	textInput textinput.Model
+	progress  progress.Model
+	paksDir   string
+	outputDir string
+	filters   []string
+	ctx       context.Context
+	cancel    context.CancelFunc
+	lines     chan string
+	log       []string
+	done      int
+	total     int
+	startTime time.Time
+	result    UnpackResult
+	err       error
 }
 
+type unpackTickMsg time.Time
+
 func NewUnpackSetupModel() UnpackSetupModel {
-	return UnpackSetupModel{}
+	ti := textinput.New()
+	ti.Placeholder = "Enter directory path..."
+	ti.Focus()
+	ti.Width = 60
+	ti.SetValue(config.Active().PakDir)
+
+	return UnpackSetupModel{
+		step:      unpackStepPaksDir,
+		textInput: ti,
+		progress:  progress.New(progress.WithDefaultGradient(), progress.WithWidth(60)),
+		outputDir: config.Active().OutputDir,
+	}
+}
+
+// Extract only the assets matching filters, skipping straight to the output directory
+func NewSelectiveUnpackModel(paksDir string, filters []string) UnpackSetupModel {
+	m := NewUnpackSetupModel()
+	m.step = unpackStepOutputDir
+	m.paksDir = paksDir
+	m.filters = filters
+	m.textInput.SetValue(m.outputDir)
+	return m
 }
 
 func (m UnpackSetupModel) Init() tea.Cmd {
-	return nil
+	return textinput.Blink
 }
 
 func (m UnpackSetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+c":
+		if m.step == unpackStepRunning {
+			switch msg.String() {
+			case "ctrl+c":
+				m.cancel()
+				return m, tea.Quit
+
+			case "esc":
+				// Wait for retoc to exit and report the cancellation
+				m.cancel()
+			}
+			return m, nil
+		}
+
+		if m.step == unpackStepComplete {
+			switch msg.String() {
+			case "ctrl+c", "esc", "enter":
+				return m, tea.Quit
+			}
+			return m, nil
+		}
+
+		switch msg.Type {
+		case tea.KeyCtrlC:
 			return m, tea.Quit
 
-		case "esc":
+		case tea.KeyEsc:
 			return m, tea.Quit
 
-		case "backspace":
-			return m, func() tea.Msg { return ui.BackMsg{} }
+		case tea.KeyBackspace:
+			if m.textInput.Value() == "" {
+				if m.step == unpackStepOutputDir {
+					m.step = unpackStepPaksDir
+					m.textInput.SetValue(m.paksDir)
+					m.err = nil
+					return m, nil
+				}
+				return m, func() tea.Msg { return ui.BackMsg{} }
+			}
+
+		case tea.KeyEnter:
+			return m.handleEnter()
+		}
+
+	case UnpackLineMsg:
+		m.log = append(m.log, msg.Line)
+		if done, total, ok := parseProgress(msg.Line); ok {
+			m.done, m.total = done, total
+		}
+		return m, waitForUnpackLine(m.lines)
+
+	case unpackTickMsg:
+		if m.step == unpackStepRunning {
+			return m, unpackTick()
 		}
+		return m, nil
+
+	case UnpackCompleteMsg:
+		m.step = unpackStepComplete
+		m.result = msg.Result
+		m.err = msg.Err
+		return m, nil
+	}
+
+	if m.step == unpackStepPaksDir || m.step == unpackStepOutputDir {
+		m.textInput, cmd = m.textInput.Update(msg)
+	}
+
+	return m, cmd
+}
+
+func (m UnpackSetupModel) handleEnter() (tea.Model, tea.Cmd) {
+	normalized, err := config.NormalizePath(m.textInput.Value())
+	if err != nil {
+		m.err = fmt.Errorf("invalid path: %w", err)
+		return m, nil
+	}
+
+	switch m.step {
+	case unpackStepPaksDir:
+		if _, err := os.Stat(normalized); err != nil {
+			m.err = fmt.Errorf("directory not found: %s", normalized)
+			return m, nil
+		}
+
+		m.paksDir = normalized
+		if config.Active().PakDir == "" {
+			config.Active().PakDir = normalized
+			if err := config.SaveConfig(); err != nil {
+				m.err = fmt.Errorf("failed to save config: %w", err)
+				return m, nil
+			}
+		}
+
+		m.step = unpackStepOutputDir
+		m.textInput.SetValue(m.outputDir)
+		m.err = nil
+		return m, nil
+
+	case unpackStepOutputDir:
+		if err := config.SetOutputDir(normalized); err != nil {
+			m.err = fmt.Errorf("failed to set output directory: %w", err)
+			return m, nil
+		}
+
+		m.outputDir = normalized
+		m.err = nil
+		return m.startUnpack()
 	}
 
 	return m, nil
 }
 
+// Launch retoc to-legacy in the background
+func (m UnpackSetupModel) startUnpack() (tea.Model, tea.Cmd) {
+	m.step = unpackStepRunning
+	m.startTime = time.Now()
+	m.log = nil
+	m.done, m.total = 0, 0
+	m.lines = make(chan string, 64)
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+
+	return m, tea.Batch(
+		UnpackAsync(m.ctx, m.lines, m.paksDir, m.outputDir, m.filters),
+		waitForUnpackLine(m.lines),
+		unpackTick(),
+	)
+}
+
+func unpackTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return unpackTickMsg(t)
+	})
+}
+
 func (m UnpackSetupModel) View() string {
 	s := ui.TitleStyle.Render("Unpack Setup") + "\n\n"
-	s += ui.InfoStyle.Render("Unpack functionality coming soon...") + "\n\n"
-	s += ui.InfoStyle.Render("Backspace: Go back • ESC: Quit")
+
+	switch m.step {
+	case unpackStepPaksDir:
+		s += ui.NormalStyle.Render("UE Game \"Paks\" Directory:") + "\n"
+		s += ui.InfoStyle.Render("  The game's Zen containers (.utoc/.ucas) to extract") + "\n"
+		s += ui.InfoStyle.Render("  Example: E:\\SteamLibrary\\steamapps\\common\\Grounded2\\Augusta\\Content\\Paks") + "\n\n"
+		s += m.textInput.View() + "\n\n"
+
+	case unpackStepOutputDir:
+		s += ui.SuccessStyle.Render("✓ Paks directory: "+m.paksDir) + "\n"
+		if len(m.filters) > 0 {
+			s += ui.InfoStyle.Render(fmt.Sprintf("  Only %d selected path(s):", len(m.filters))) + "\n"
+			s += m.filtersView()
+		}
+		s += "\n"
+		s += ui.NormalStyle.Render("Output Directory:") + "\n"
+		s += ui.InfoStyle.Render("  Where extracted assets will be saved") + "\n"
+		s += ui.InfoStyle.Render("  Example: G:\\Grounded\\Modding\\Extracted") + "\n\n"
+		s += m.textInput.View() + "\n\n"
+
+	case unpackStepRunning:
+		return m.runningView()
+
+	case unpackStepComplete:
+		return m.completeView()
+	}
+
+	if m.err != nil {
+		s += "\n" + ui.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("Enter: Confirm • Backspace: Go back • ESC: Quit")
+	return s
+}
+
+func (m UnpackSetupModel) runningView() string {
+	elapsed := time.Since(m.startTime).Round(time.Second)
+
+	s := ui.BuildingStyle.Render("⚙️  Unpacking: "+m.paksDir) + "\n"
+	if len(m.filters) > 0 {
+		s += ui.InfoStyle.Render(fmt.Sprintf("Selected paths: %d", len(m.filters))) + "\n"
+	}
+	s += fmt.Sprintf("Elapsed: %s\n\n", elapsed)
+
+	if m.total > 0 {
+		percent := float64(m.done) / float64(m.total)
+		s += m.progress.ViewAs(percent) + "\n"
+		s += ui.InfoStyle.Render(fmt.Sprintf("%d/%d assets", m.done, m.total)) + "\n\n"
+	} else {
+		s += ui.InfoStyle.Render("Scanning containers...") + "\n\n"
+	}
+
+	start := 0
+	if len(m.log) > 10 {
+		start = len(m.log) - 10
+	}
+	for _, line := range m.log[start:] {
+		if _, _, ok := parseProgress(line); ok {
+			continue
+		}
+		s += ui.InfoStyle.Render(line) + "\n"
+	}
+
+	s += "\nPress ESC to cancel"
+	return s
+}
+
+// List the selected paths, truncated to keep the screen short
+func (m UnpackSetupModel) filtersView() string {
+	const shown = 8
+
+	s := ""
+	for i, filter := range m.filters {
+		if i == shown {
+			s += ui.InfoStyle.Render(fmt.Sprintf("    ... and %d more", len(m.filters)-shown)) + "\n"
+			break
+		}
+		s += ui.InfoStyle.Render("    "+filter) + "\n"
+	}
+	return s
+}
+
+func (m UnpackSetupModel) completeView() string {
+	s := ui.TitleStyle.Render("Unpack Complete") + "\n\n"
+
+	if m.err != nil {
+		s += ui.ErrorStyle.Render("✗ ") + ui.NormalStyle.Render(m.err.Error()) + "\n\n"
+
+		output := strings.Split(strings.TrimSpace(m.result.Output), "\n")
+		start := 0
+		if len(output) > 10 {
+			start = len(output) - 10
+		}
+		for _, line := range output[start:] {
+			s += ui.InfoStyle.Render(line) + "\n"
+		}
+	} else {
+		s += ui.SuccessStyle.Render("✓ ") + ui.NormalStyle.Render(fmt.Sprintf("Extracted %d asset(s)", m.result.Extracted)) + "\n"
+		if m.result.Failed > 0 {
+			s += ui.ErrorStyle.Render("✗ ") + ui.NormalStyle.Render(fmt.Sprintf("%d asset(s) failed", m.result.Failed)) + "\n"
+		}
+		s += ui.InfoStyle.Render("  Output: "+m.result.OutputDir) + "\n"
+	}
+
+	s += "\n" + ui.InfoStyle.Render("Enter/ESC: Back to Retoc menu")
 	return s
 }
@@ -0,0 +1,42 @@
+package retoc
+
+import (
+	"fmt"
+
+	"github.com/JaceTheGrayOne/TINKR-Toolkit/modules/config"
+)
+
+// Engine versions accepted by retoc's --version flag
+var EngineVersions = []string{
+	"UE4_25",
+	"UE4_26",
+	"UE4_27",
+	"UE5_0",
+	"UE5_1",
+	"UE5_2",
+	"UE5_3",
+	"UE5_4",
+	"UE5_5",
+	"UE5_6",
+}
+
+// Check that version is one retoc understands
+func ValidateEngineVersion(version string) error {
+	for _, v := range EngineVersions {
+		if v == version {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported engine version: %s", version)
+}
+
+// Resolve the engine version used to build a mod
+func engineVersionFor(mod Mod) string {
+	if mod.EngineVersion != "" {
+		return mod.EngineVersion
+	}
+	if config.Active().EngineVersion != "" {
+		return config.Active().EngineVersion
+	}
+	return config.DefaultEngineVersion
+}
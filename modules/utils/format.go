@@ -1,9 +1,17 @@
 package utils
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 // Normalize directory name
 func FormatDisplayName(folderName string) string {
+	return strings.ReplaceAll(StripLoadOrder(folderName), "_", " ")
+}
+
+// Strip the z_ priority prefix and _NNNN_P patch suffix from a pak or folder name
+func StripLoadOrder(folderName string) string {
 	name := strings.TrimPrefix(folderName, "z_")
 	name = strings.TrimPrefix(name, "Z_")
 
@@ -28,6 +36,20 @@ func FormatDisplayName(folderName string) string {
 		}
 	}
 
-	name = strings.ReplaceAll(name, "_", " ")
 	return name
 }
+
+// Human-readable byte size
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}